@@ -0,0 +1,168 @@
+package algebra
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// Affine is a 2D affine transform in homogeneous coordinates. A point (x, y)
+// maps to (m[0][0]x + m[0][1]y + m[0][2], m[1][0]x + m[1][1]y + m[1][2]); the
+// last row is always 0 0 1.
+type Affine [3][3]float64
+
+// Decomposition splits an affine transform into simple parts. Compose
+// rebuilds the transform as translation * rotation * shear * scale.
+type Decomposition struct {
+	Translation vector2.Vector2
+	// Rotation in radians.
+	Rotation float64
+	// Shear is the x shear factor applied before rotation.
+	Shear float64
+	// Scale along the x and y axes. A mirrored transform has a negative Y.
+	Scale vector2.Vector2
+}
+
+func Identity() Affine {
+	return Affine{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+}
+
+// NewAffine returns the transform with linear part [a c; b d] and
+// translation (e, f), using the same argument order as an SVG matrix.
+func NewAffine(a, b, c, d, e, f float64) Affine {
+	return Affine{{a, c, e}, {b, d, f}, {0, 0, 1}}
+}
+
+func Translation(x, y float64) Affine {
+	return NewAffine(1, 0, 0, 1, x, y)
+}
+
+// Rotation rotates counter-clockwise, in a y-up frame, by angle radians
+// about the origin.
+func Rotation(angle float64) Affine {
+	sin, cos := math.Sincos(angle)
+	return NewAffine(cos, sin, -sin, cos, 0, 0)
+}
+
+// RotationAbout rotates by angle radians about center.
+func RotationAbout(angle float64, center vector2.Vector2) Affine {
+	return Translation(center.X, center.Y).Mul(Rotation(angle)).Mul(Translation(-center.X, -center.Y))
+}
+
+func Scaling(sx, sy float64) Affine {
+	return NewAffine(sx, 0, 0, sy, 0, 0)
+}
+
+// Shear moves x by shx*y and y by shy*x.
+func Shear(shx, shy float64) Affine {
+	return NewAffine(1, shy, shx, 1, 0, 0)
+}
+
+// Mirror reflects across the line through the origin at angle radians from
+// the x axis.
+func Mirror(angle float64) Affine {
+	sin, cos := math.Sincos(2 * angle)
+	return NewAffine(cos, sin, sin, -cos, 0, 0)
+}
+
+// Mul returns the transform that applies n first and then m.
+func (m Affine) Mul(n Affine) Affine {
+	var r Affine
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j] + m[i][2]*n[2][j]
+		}
+	}
+	return r
+}
+
+// Then returns the transform that applies m first and then n.
+func (m Affine) Then(n Affine) Affine {
+	return n.Mul(m)
+}
+
+// Determinant of the linear part. It is negative for mirroring transforms.
+func (m Affine) Determinant() float64 {
+	return m[0][0]*m[1][1] - m[0][1]*m[1][0]
+}
+
+// Inverse returns the inverse transform. It reports false when the transform
+// is singular.
+func (m Affine) Inverse() (Affine, bool) {
+	det := m.Determinant()
+	if math.Abs(det) < 1e-12 {
+		return Affine{}, false
+	}
+	a, c, e := m[0][0], m[0][1], m[0][2]
+	b, d, f := m[1][0], m[1][1], m[1][2]
+	return NewAffine(
+		d/det, -b/det,
+		-c/det, a/det,
+		(c*f-d*e)/det, (b*e-a*f)/det,
+	), true
+}
+
+// Apply transforms a point.
+func (m Affine) Apply(v vector2.Vector2) vector2.Vector2 {
+	return vector2.Vector2{
+		X: m[0][0]*v.X + m[0][1]*v.Y + m[0][2],
+		Y: m[1][0]*v.X + m[1][1]*v.Y + m[1][2],
+	}
+}
+
+// ApplyVector transforms a direction, ignoring the translation.
+func (m Affine) ApplyVector(v vector2.Vector2) vector2.Vector2 {
+	return vector2.Vector2{
+		X: m[0][0]*v.X + m[0][1]*v.Y,
+		Y: m[1][0]*v.X + m[1][1]*v.Y,
+	}
+}
+
+// IsSimilarity reports whether the transform only rotates, mirrors, scales
+// uniformly and translates, so that circles stay circles.
+func (m Affine) IsSimilarity() bool {
+	const eps = 1e-9
+	col1 := math.Hypot(m[0][0], m[1][0])
+	col2 := math.Hypot(m[0][1], m[1][1])
+	dot := m[0][0]*m[0][1] + m[1][0]*m[1][1]
+	return math.Abs(col1-col2) <= eps*math.Max(col1, 1) && math.Abs(dot) <= eps*math.Max(col1*col2, 1)
+}
+
+// IsAxisAligned reports whether axis aligned rectangles stay axis aligned.
+func (m Affine) IsAxisAligned() bool {
+	return m[0][1] == 0 && m[1][0] == 0
+}
+
+// ScaleFactor returns the uniform scale of a similarity transform, or the
+// geometric mean of the axis scales otherwise.
+func (m Affine) ScaleFactor() float64 {
+	return math.Sqrt(math.Abs(m.Determinant()))
+}
+
+// Decompose splits the transform into translation, rotation, shear and
+// scale.
+func (m Affine) Decompose() Decomposition {
+	a, b, c, d := m[0][0], m[1][0], m[0][1], m[1][1]
+	sx := math.Hypot(a, b)
+	dec := Decomposition{
+		Translation: vector2.Vector2{X: m[0][2], Y: m[1][2]},
+		Rotation:    math.Atan2(b, a),
+	}
+	if sx == 0 {
+		return dec
+	}
+	sy := (a*d - b*c) / sx
+	dec.Scale = vector2.Vector2{X: sx, Y: sy}
+	if sy != 0 {
+		dec.Shear = (a*c + b*d) / (sx * sy)
+	}
+	return dec
+}
+
+// Compose rebuilds the transform described by the decomposition.
+func (d Decomposition) Compose() Affine {
+	return Translation(d.Translation.X, d.Translation.Y).
+		Mul(Rotation(d.Rotation)).
+		Mul(Shear(d.Shear, 0)).
+		Mul(Scaling(d.Scale.X, d.Scale.Y))
+}
@@ -0,0 +1,125 @@
+package dxf
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+// Units are the $INSUNITS drawing units.
+type Units int
+
+const (
+	Unitless    Units = 0
+	Inches      Units = 1
+	Feet        Units = 2
+	Millimeters Units = 4
+	Centimeters Units = 5
+	Meters      Units = 6
+)
+
+// Layer is an entry of the LAYER table.
+type Layer struct {
+	Name string
+	// Color is an AutoCAD color index from 1 to 255. Zero is not a valid
+	// layer color and is written as 7 (white/black).
+	Color int
+}
+
+// Entity is a drawing entity on a layer.
+type Entity struct {
+	Layer string
+	// Shape is a primitive.Line, Circle, Arc or Polygon. Polygons are
+	// written as POLYLINE entities. An Ellipse or EllipticalArc is written
+	// as a flattened polyline.
+	Shape primitive.Shape
+	// Closed marks a Polygon whose last vertex joins back to the first.
+	Closed bool
+	// Bulges holds, for each Polygon vertex, the bulge of the segment that
+	// leaves it: tan(sweep/4), positive for a counter-clockwise arc. It is
+	// nil when every segment is straight.
+	Bulges []float64
+}
+
+// Drawing is the content of a DXF file.
+type Drawing struct {
+	// Units are read from the $INSUNITS header variable of newer files. R12
+	// files, including those from Write, have no such variable.
+	Units    Units
+	Layers   []Layer
+	Entities []Entity
+}
+
+// Add appends a shape on the given layer. Polygons are added closed.
+func (d *Drawing) Add(layer string, s primitive.Shape) {
+	_, closed := s.(primitive.Polygon)
+	d.Entities = append(d.Entities, Entity{Layer: layer, Shape: s, Closed: closed})
+}
+
+// Shapes returns the geometry of every entity, with bulged polylines
+// expanded by Entity.Shapes.
+func (d *Drawing) Shapes() []primitive.Shape {
+	var shapes []primitive.Shape
+	for _, e := range d.Entities {
+		shapes = append(shapes, e.Shapes()...)
+	}
+	return shapes
+}
+
+// Shapes returns the entity geometry. A polyline with bulges is split into
+// its straight Line and curved Arc segments; any other entity is returned
+// as is.
+func (e Entity) Shapes() []primitive.Shape {
+	p, ok := e.Shape.(primitive.Polygon)
+	if !ok || !e.hasBulges() {
+		return []primitive.Shape{e.Shape}
+	}
+	count := len(p) - 1
+	if e.Closed {
+		count = len(p)
+	}
+	shapes := make([]primitive.Shape, 0, count)
+	for i := 0; i < count; i++ {
+		a, b := p[i], p[(i+1)%len(p)]
+		if bulge := e.Bulges[i]; bulge != 0 {
+			shapes = append(shapes, BulgeArc(a, b, bulge))
+		} else {
+			shapes = append(shapes, primitive.Line{Start: a, End: b})
+		}
+	}
+	return shapes
+}
+
+func (e Entity) hasBulges() bool {
+	for _, b := range e.Bulges {
+		if b != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// BulgeArc returns the arc from start to end described by a polyline bulge.
+func BulgeArc(start, end vector2.Vector2, bulge float64) primitive.Arc {
+	sweep := 4 * math.Atan(bulge)
+	chord := end.Sub(start)
+	mid := start.Add(chord.Divf(2))
+	// The centre lies on the chord bisector, to the left of the chord for a
+	// counter-clockwise sweep under half a turn.
+	offset := 1 / (2 * math.Tan(sweep/2))
+	center := vector2.Vector2{X: mid.X - chord.Y*offset, Y: mid.Y + chord.X*offset}
+	radius := math.Abs(chord.Length() / (2 * math.Sin(sweep/2)))
+	angle := center.AngleToPoint(start)
+	return primitive.NewArc(center.X, center.Y, radius, angle, angle+sweep)
+}
+
+// ArcBulge returns the bulge of a polyline segment that follows the arc.
+func ArcBulge(a primitive.Arc) float64 {
+	return math.Tan(a.Sweep() / 4)
+}
+
+// radians converts a DXF angle, which is stored in degrees.
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
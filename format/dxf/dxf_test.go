@@ -0,0 +1,163 @@
+package dxf
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+func roundTrip(t *testing.T, d *Drawing) (*Drawing, string) {
+	t.Helper()
+	var b bytes.Buffer
+	if err := Write(&b, d); err != nil {
+		t.Fatal(err)
+	}
+	text := b.String()
+	got, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got, text
+}
+
+// sameArc reports whether two arcs share their circle, ends and sweep, to
+// within round-off from the conversion of the angles to degrees.
+func sameArc(a, b primitive.Arc) bool {
+	tol := 1e-12 * (1 + b.Circle.Radius + b.Circle.Center.Length())
+	return a.Circle == b.Circle &&
+		a.StartPoint().DistanceTo(b.StartPoint()) <= tol &&
+		a.EndPoint().DistanceTo(b.EndPoint()) <= tol &&
+		math.Abs(a.Sweep()-b.Sweep()) <= 1e-12
+}
+
+func TestRoundTrip(t *testing.T) {
+	d := &Drawing{Units: Millimeters, Layers: []Layer{{Name: "cut", Color: 1}, {Name: "blank"}}}
+	d.Add("cut", primitive.Line{Start: vector2.Vector2{X: 0.1, Y: 0.2}, End: vector2.Vector2{X: 1.0 / 3, Y: -7}})
+	d.Add("cut", primitive.NewCircle(1.5, -2.25, math.Sqrt2))
+	d.Add("", primitive.Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}})
+	d.Entities = append(d.Entities, Entity{
+		Layer:  "open",
+		Shape:  primitive.Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}},
+		Bulges: []float64{0, 0.5, 0},
+	})
+
+	got, text := roundTrip(t, d)
+	if !strings.Contains(text, "AC1009") || strings.Contains(text, "LWPOLYLINE") || strings.Contains(text, "$INSUNITS") {
+		t.Errorf("expected an R12 file with POLYLINE entities and no later header variables:\n%s", text)
+	}
+	if got.Units != Unitless {
+		t.Errorf("units = %v, want %v", got.Units, Unitless)
+	}
+	if len(got.Entities) != len(d.Entities) {
+		t.Fatalf("got %d entities, want %d", len(got.Entities), len(d.Entities))
+	}
+	for i, want := range d.Entities {
+		e := got.Entities[i]
+		if layerName(want.Layer) != e.Layer || want.Closed != e.Closed {
+			t.Errorf("entity %d: layer %q closed %v, want %q %v", i, e.Layer, e.Closed, layerName(want.Layer), want.Closed)
+		}
+		switch w := want.Shape.(type) {
+		case primitive.Polygon:
+			p, ok := e.Shape.(primitive.Polygon)
+			if !ok || len(p) != len(w) {
+				t.Fatalf("entity %d: got %v, want %v", i, e.Shape, w)
+			}
+			for j := range w {
+				if p[j] != w[j] {
+					t.Errorf("entity %d: vertex %d = %v, want %v", i, j, p[j], w[j])
+				}
+			}
+			for j, b := range want.Bulges {
+				if e.Bulges[j] != b {
+					t.Errorf("entity %d: bulge %d = %g, want %g", i, j, e.Bulges[j], b)
+				}
+			}
+		default:
+			if e.Shape != want.Shape {
+				t.Errorf("entity %d: got %v, want %v", i, e.Shape, want.Shape)
+			}
+		}
+	}
+
+	colors := map[string]int{}
+	for _, l := range got.Layers {
+		colors[l.Name] = l.Color
+	}
+	// Layer color 0 is not valid in the table and comes back as 7.
+	for name, want := range map[string]int{"0": 7, "cut": 1, "blank": 7, "open": 7} {
+		if colors[name] != want {
+			t.Errorf("layer %q color = %d, want %d", name, colors[name], want)
+		}
+	}
+}
+
+func TestArcRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	d := &Drawing{}
+	for i := 0; i < 1000; i++ {
+		start := (r.Float64()*2 - 1) * 2 * math.Pi
+		sweep := (r.Float64()*2 - 1) * 2 * math.Pi
+		d.Add("0", primitive.NewArc(r.Float64()*100-50, r.Float64()*100-50, r.Float64()*10+0.1, start, start+sweep))
+	}
+	got, _ := roundTrip(t, d)
+	if len(got.Entities) != len(d.Entities) {
+		t.Fatalf("got %d entities, want %d", len(got.Entities), len(d.Entities))
+	}
+	for i, e := range got.Entities {
+		want := d.Entities[i].Shape.(primitive.Arc)
+		if arc := e.Shape.(primitive.Arc); !sameArc(arc, want) {
+			t.Fatalf("arc %d = %+v, want %+v", i, arc, want)
+		}
+	}
+}
+
+func TestArcRoundTripRightAngles(t *testing.T) {
+	d := &Drawing{}
+	d.Add("0", primitive.NewArc(0, 0, 10, 0, math.Pi/2))
+	d.Add("0", primitive.NewArc(0, 0, 10, math.Pi, -math.Pi/2))
+	d.Add("0", primitive.NewArc(5, 5, 1, -math.Pi/4, 3*math.Pi/4))
+	got, text := roundTrip(t, d)
+	for _, degrees := range []string{"\n90\n", "\n0\n", "\n315\n", "\n135\n"} {
+		if !strings.Contains(text, degrees) {
+			t.Errorf("expected angle %q in:\n%s", strings.TrimSpace(degrees), text)
+		}
+	}
+	for i, e := range got.Entities {
+		if want := d.Entities[i].Shape.(primitive.Arc); !sameArc(e.Shape.(primitive.Arc), want) {
+			t.Errorf("arc %d = %+v, want %+v", i, e.Shape, want)
+		}
+	}
+	cw := got.Entities[1].Shape.(primitive.Arc)
+	if mid := cw.PointAt(cw.AngleStart + cw.Sweep()/2); mid.X < 0 || mid.Y < 0 {
+		t.Errorf("clockwise arc midpoint %v, want it in the first quadrant", mid)
+	}
+}
+
+func TestEllipsesWrittenFlattened(t *testing.T) {
+	ellipse := primitive.NewEllipse(3, 4, 5, 2, math.Pi/6)
+	arc := primitive.NewEllipticalArc(3, 4, 5, 2, math.Pi/6, 0.5, -2)
+	d := &Drawing{}
+	d.Add("0", ellipse)
+	d.Add("0", arc)
+	got, _ := roundTrip(t, d)
+	if len(got.Entities) != 2 {
+		t.Fatalf("got %d entities, want 2", len(got.Entities))
+	}
+	for i, want := range []primitive.Shape{ellipse, arc} {
+		e := got.Entities[i]
+		p, ok := e.Shape.(primitive.Polygon)
+		if !ok || e.Closed != (i == 0) {
+			t.Fatalf("entity %d: got %T closed %v", i, e.Shape, e.Closed)
+		}
+		for _, v := range p {
+			if d := math.Abs(want.SignedDistance(v)); d > primitive.DefaultTolerance {
+				t.Fatalf("entity %d: vertex %v is %g off the curve", i, v, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package dxf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+// pair is a group code and its value.
+type pair struct {
+	code  int
+	value string
+	line  int
+}
+
+// Parse reads an ASCII DXF file. LINE, CIRCLE, ARC, LWPOLYLINE and 2D
+// POLYLINE entities in the ENTITIES section are converted to primitives;
+// other entities are skipped. Entities drawn with a flipped extrusion
+// direction are mirrored into world coordinates.
+func Parse(r io.Reader) (*Drawing, error) {
+	pairs, err := readPairs(r)
+	if err != nil {
+		return nil, err
+	}
+	d := &Drawing{}
+	for i := 0; i < len(pairs); {
+		if pairs[i].code != 0 || pairs[i].value != "SECTION" || i+1 >= len(pairs) {
+			i++
+			continue
+		}
+		name := pairs[i+1].value
+		end := i + 2
+		for end < len(pairs) && !(pairs[end].code == 0 && pairs[end].value == "ENDSEC") {
+			end++
+		}
+		section := pairs[i+2 : end]
+		switch name {
+		case "HEADER":
+			d.parseHeader(section)
+		case "TABLES":
+			d.parseLayers(section)
+		case "ENTITIES":
+			if err := d.parseEntities(section); err != nil {
+				return nil, err
+			}
+		}
+		i = end + 1
+	}
+	return d, nil
+}
+
+func readPairs(r io.Reader) ([]pair, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var pairs []pair
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		code, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fmt.Errorf("dxf: line %d: invalid group code %q", line, text)
+		}
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("dxf: line %d: group code %d has no value", line, code)
+		}
+		line++
+		pairs = append(pairs, pair{code: code, value: strings.TrimSpace(scanner.Text()), line: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+func (d *Drawing) parseHeader(section []pair) {
+	for i := 0; i+1 < len(section); i++ {
+		if section[i].code == 9 && section[i].value == "$INSUNITS" && section[i+1].code == 70 {
+			units, _ := strconv.Atoi(section[i+1].value)
+			d.Units = Units(units)
+		}
+	}
+}
+
+func (d *Drawing) parseLayers(section []pair) {
+	for _, record := range records(section) {
+		if record[0].value != "LAYER" {
+			continue
+		}
+		var l Layer
+		for _, p := range record[1:] {
+			switch p.code {
+			case 2:
+				l.Name = p.value
+			case 62:
+				// A negative color marks a layer that is switched off.
+				color, _ := strconv.Atoi(p.value)
+				if color < 0 {
+					color = -color
+				}
+				l.Color = color
+			}
+		}
+		if l.Name != "" {
+			d.Layers = append(d.Layers, l)
+		}
+	}
+}
+
+func (d *Drawing) parseEntities(section []pair) error {
+	recs := records(section)
+	for i := 0; i < len(recs); i++ {
+		record := recs[i]
+		var (
+			e   Entity
+			ok  bool
+			err error
+		)
+		switch record[0].value {
+		case "LINE":
+			e, ok, err = parseLine(record)
+		case "CIRCLE":
+			e, ok, err = parseCircle(record)
+		case "ARC":
+			e, ok, err = parseArc(record)
+		case "LWPOLYLINE":
+			e, ok, err = parseLWPolyline(record)
+		case "POLYLINE":
+			end := i + 1
+			for end < len(recs) && recs[end][0].value == "VERTEX" {
+				end++
+			}
+			e, ok, err = parsePolyline(record, recs[i+1:end])
+			i = end - 1
+		}
+		if err != nil {
+			return err
+		}
+		if ok {
+			d.Entities = append(d.Entities, e)
+		}
+	}
+	return nil
+}
+
+// records splits pairs into groups that each start with a code 0 pair.
+func records(pairs []pair) [][]pair {
+	var recs [][]pair
+	for _, p := range pairs {
+		if p.code == 0 {
+			recs = append(recs, []pair{p})
+		} else if len(recs) > 0 {
+			recs[len(recs)-1] = append(recs[len(recs)-1], p)
+		}
+	}
+	return recs
+}
+
+// fields holds the numeric values of an entity record by group code. Codes
+// that repeat, such as polyline vertices, keep only their last value.
+type fields struct {
+	layer  string
+	values map[int]float64
+}
+
+func readFields(record []pair) (fields, error) {
+	f := fields{layer: "0", values: map[int]float64{}}
+	for _, p := range record[1:] {
+		if p.code == 8 {
+			f.layer = p.value
+			continue
+		}
+		if !isNumeric(p.code) {
+			continue
+		}
+		v, err := strconv.ParseFloat(p.value, 64)
+		if err != nil {
+			return f, fmt.Errorf("dxf: line %d: invalid value %q for group code %d", p.line, p.value, p.code)
+		}
+		f.values[p.code] = v
+	}
+	return f, nil
+}
+
+// isNumeric reports whether a group code carries a number.
+func isNumeric(code int) bool {
+	return (code >= 10 && code <= 59) || (code >= 60 && code <= 99) ||
+		(code >= 140 && code <= 147) || (code >= 170 && code <= 175) || (code >= 210 && code <= 239)
+}
+
+// mirrored reports whether the entity's extrusion direction points down the
+// Z axis, in which case its object coordinates have X reversed.
+func (f fields) mirrored() bool {
+	z, ok := f.values[230]
+	return ok && z < 0
+}
+
+func (f fields) point(xCode int) vector2.Vector2 {
+	p := vector2.Vector2{X: f.values[xCode], Y: f.values[xCode+10]}
+	if f.mirrored() {
+		p.X = -p.X
+	}
+	return p
+}
+
+func parseLine(record []pair) (Entity, bool, error) {
+	f, err := readFields(record)
+	if err != nil {
+		return Entity{}, false, err
+	}
+	// LINE endpoints are in world coordinates regardless of extrusion.
+	start := vector2.Vector2{X: f.values[10], Y: f.values[20]}
+	end := vector2.Vector2{X: f.values[11], Y: f.values[21]}
+	return Entity{Layer: f.layer, Shape: primitive.Line{Start: start, End: end}}, true, nil
+}
+
+func parseCircle(record []pair) (Entity, bool, error) {
+	f, err := readFields(record)
+	if err != nil {
+		return Entity{}, false, err
+	}
+	c := f.point(10)
+	return Entity{Layer: f.layer, Shape: primitive.NewCircle(c.X, c.Y, f.values[40])}, true, nil
+}
+
+func parseArc(record []pair) (Entity, bool, error) {
+	f, err := readFields(record)
+	if err != nil {
+		return Entity{}, false, err
+	}
+	c := f.point(10)
+	start := radians(f.values[50])
+	end := radians(f.values[51])
+	for end <= start {
+		end += math.Pi * 2
+	}
+	if f.mirrored() {
+		// Mirroring turns the counter-clockwise arc clockwise.
+		start, end = math.Pi-start, math.Pi-end
+	}
+	return Entity{Layer: f.layer, Shape: primitive.NewArc(c.X, c.Y, f.values[40], start, end)}, true, nil
+}
+
+func parseLWPolyline(record []pair) (Entity, bool, error) {
+	f, err := readFields(record)
+	if err != nil {
+		return Entity{}, false, err
+	}
+	e := Entity{Layer: f.layer, Closed: int(f.values[70])&1 != 0}
+	var points primitive.Polygon
+	var bulges []float64
+	for _, p := range record[1:] {
+		switch p.code {
+		case 10, 20, 42:
+			v, err := strconv.ParseFloat(p.value, 64)
+			if err != nil {
+				return Entity{}, false, fmt.Errorf("dxf: line %d: invalid value %q for group code %d", p.line, p.value, p.code)
+			}
+			switch p.code {
+			case 10:
+				points = append(points, vector2.Vector2{X: v})
+				bulges = append(bulges, 0)
+			case 20:
+				if len(points) > 0 {
+					points[len(points)-1].Y = v
+				}
+			case 42:
+				if len(bulges) > 0 {
+					bulges[len(bulges)-1] = v
+				}
+			}
+		}
+	}
+	return polylineEntity(e, points, bulges, f.mirrored())
+}
+
+func parsePolyline(record []pair, vertices [][]pair) (Entity, bool, error) {
+	f, err := readFields(record)
+	if err != nil {
+		return Entity{}, false, err
+	}
+	flags := int(f.values[70])
+	// Polygon meshes and polyface meshes are surfaces, not outlines.
+	if flags&(16|64) != 0 {
+		return Entity{}, false, nil
+	}
+	e := Entity{Layer: f.layer, Closed: flags&1 != 0}
+	var points primitive.Polygon
+	var bulges []float64
+	for _, vertex := range vertices {
+		vf, err := readFields(vertex)
+		if err != nil {
+			return Entity{}, false, err
+		}
+		points = append(points, vector2.Vector2{X: vf.values[10], Y: vf.values[20]})
+		bulges = append(bulges, vf.values[42])
+	}
+	// 3D polylines are in world coordinates.
+	return polylineEntity(e, points, bulges, flags&8 == 0 && f.mirrored())
+}
+
+func polylineEntity(e Entity, points primitive.Polygon, bulges []float64, mirrored bool) (Entity, bool, error) {
+	if len(points) < 2 {
+		return Entity{}, false, nil
+	}
+	if mirrored {
+		for i := range points {
+			points[i].X = -points[i].X
+			bulges[i] = -bulges[i]
+		}
+	}
+	e.Shape = points
+	for _, b := range bulges {
+		if b != 0 {
+			e.Bulges = bulges
+			break
+		}
+	}
+	return e, true, nil
+}
@@ -0,0 +1,217 @@
+package dxf
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+// Write writes the drawing as an AutoCAD R12 (AC1009) ASCII DXF file with
+// HEADER, TABLES and ENTITIES sections. Every layer used by an entity is
+// added to the layer table, and polygons are written as POLYLINE entities
+// with VERTEX records. Reading the file back with Parse returns the same
+// geometry, within these limits:
+//
+//   - Coordinates are written with full float precision and read back exactly.
+//   - Arc angles are stored in degrees within [0, 360), so an arc reads back
+//     with the same ends and sweep but its angles may differ by whole turns,
+//     and the conversion to degrees and back can move them by a few units in
+//     the last place.
+//   - Clockwise arcs are written with a flipped extrusion direction, so they
+//     read back clockwise. Their angles are stored as seen from below, as pi
+//     minus the angle.
+//   - Drawing units are not written: R12 has no header variable for them, so
+//     the file reads back Unitless.
+//   - Ellipses and elliptical arcs are written as polylines that stay within
+//     primitive.DefaultTolerance of the curve, and read back as polygons.
+//   - Layer color 0 is not a valid layer color and is written as 7.
+func Write(w io.Writer, d *Drawing) error {
+	bw := bufio.NewWriter(w)
+	out := &writer{w: bw}
+
+	out.pair(0, "SECTION")
+	out.pair(2, "HEADER")
+	out.pair(9, "$ACADVER")
+	out.pair(1, "AC1009")
+	out.pair(0, "ENDSEC")
+
+	layers := d.layerTable()
+	out.pair(0, "SECTION")
+	out.pair(2, "TABLES")
+	out.pair(0, "TABLE")
+	out.pair(2, "LTYPE")
+	out.pair(70, "1")
+	out.pair(0, "LTYPE")
+	out.pair(2, "CONTINUOUS")
+	out.pair(70, "0")
+	out.pair(3, "Solid line")
+	out.pair(72, "65")
+	out.pair(73, "0")
+	out.number(40, 0)
+	out.pair(0, "ENDTAB")
+	out.pair(0, "TABLE")
+	out.pair(2, "LAYER")
+	out.pair(70, strconv.Itoa(len(layers)))
+	for _, l := range layers {
+		color := l.Color
+		if color == 0 {
+			color = 7
+		}
+		out.pair(0, "LAYER")
+		out.pair(2, l.Name)
+		out.pair(70, "0")
+		out.pair(62, strconv.Itoa(color))
+		out.pair(6, "CONTINUOUS")
+	}
+	out.pair(0, "ENDTAB")
+	out.pair(0, "ENDSEC")
+
+	out.pair(0, "SECTION")
+	out.pair(2, "ENTITIES")
+	for _, e := range d.Entities {
+		if err := out.entity(e); err != nil {
+			return err
+		}
+	}
+	out.pair(0, "ENDSEC")
+	out.pair(0, "EOF")
+	return bw.Flush()
+}
+
+// layerTable returns the declared layers followed by any layer that is only
+// named by an entity.
+func (d *Drawing) layerTable() []Layer {
+	layers := append([]Layer{}, d.Layers...)
+	seen := map[string]bool{}
+	for _, l := range layers {
+		seen[l.Name] = true
+	}
+	if !seen["0"] {
+		layers = append([]Layer{{Name: "0"}}, layers...)
+		seen["0"] = true
+	}
+	for _, e := range d.Entities {
+		name := layerName(e.Layer)
+		if !seen[name] {
+			layers = append(layers, Layer{Name: name})
+			seen[name] = true
+		}
+	}
+	return layers
+}
+
+func layerName(name string) string {
+	if name == "" {
+		return "0"
+	}
+	return name
+}
+
+type writer struct {
+	w io.Writer
+}
+
+func (o *writer) pair(code int, value string) {
+	fmt.Fprintf(o.w, "%3d\n%s\n", code, value)
+}
+
+func (o *writer) number(code int, v float64) {
+	if v == 0 {
+		// Write negative zero, as left by mirroring, as 0.
+		v = 0
+	}
+	o.pair(code, strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+func (o *writer) entity(e Entity) error {
+	switch s := e.Shape.(type) {
+	case primitive.Line:
+		o.pair(0, "LINE")
+		o.pair(8, layerName(e.Layer))
+		o.number(10, s.Start.X)
+		o.number(20, s.Start.Y)
+		o.number(11, s.End.X)
+		o.number(21, s.End.Y)
+	case primitive.Circle:
+		o.pair(0, "CIRCLE")
+		o.pair(8, layerName(e.Layer))
+		o.number(10, s.Center.X)
+		o.number(20, s.Center.Y)
+		o.number(40, s.Radius)
+	case primitive.Arc:
+		// ARC entities run counter-clockwise in their object coordinates. A
+		// clockwise arc is counter-clockwise seen from below, so it is written
+		// with the extrusion direction flipped, which mirrors X.
+		clockwise := s.Sweep() < 0
+		center := s.Circle.Center
+		if clockwise {
+			center.X = -center.X
+		}
+		o.pair(0, "ARC")
+		o.pair(8, layerName(e.Layer))
+		o.number(10, center.X)
+		o.number(20, center.Y)
+		o.number(40, s.Circle.Radius)
+		o.angle(50, s.AngleStart, clockwise)
+		o.angle(51, s.AngleEnd, clockwise)
+		if clockwise {
+			o.number(210, 0)
+			o.number(220, 0)
+			o.number(230, -1)
+		}
+	case primitive.Polygon:
+		if e.Bulges != nil && len(e.Bulges) != len(s) {
+			return fmt.Errorf("dxf: %d bulges for a polyline of %d vertices", len(e.Bulges), len(s))
+		}
+		flags := "0"
+		if e.Closed {
+			flags = "1"
+		}
+		o.pair(0, "POLYLINE")
+		o.pair(8, layerName(e.Layer))
+		o.pair(66, "1")
+		o.number(10, 0)
+		o.number(20, 0)
+		o.number(30, 0)
+		o.pair(70, flags)
+		for i, v := range s {
+			o.pair(0, "VERTEX")
+			o.pair(8, layerName(e.Layer))
+			o.number(10, v.X)
+			o.number(20, v.Y)
+			if e.Bulges != nil && e.Bulges[i] != 0 {
+				o.number(42, e.Bulges[i])
+			}
+		}
+		o.pair(0, "SEQEND")
+		o.pair(8, layerName(e.Layer))
+	case primitive.Ellipse, primitive.EllipticalArc:
+		// R12 has no ELLIPSE entity, so ellipses are written flattened.
+		_, closed := s.(primitive.Ellipse)
+		return o.entity(Entity{Layer: e.Layer, Shape: primitive.Polygonize(s, primitive.DefaultTolerance), Closed: closed})
+	default:
+		return fmt.Errorf("dxf: unsupported shape %T", e.Shape)
+	}
+	return nil
+}
+
+// angle writes an angle in degrees within [0, 360). Mirrored angles are
+// written as seen from below, as pi minus the angle.
+func (o *writer) angle(code int, angle float64, mirrored bool) {
+	if mirrored {
+		angle = math.Pi - angle
+	}
+	degrees := math.Mod(angle*180/math.Pi, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	if degrees >= 360 {
+		// A tiny negative angle rounds up to a full turn.
+		degrees = 0
+	}
+	o.pair(code, strconv.FormatFloat(degrees, 'f', -1, 64))
+}
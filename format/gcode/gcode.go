@@ -0,0 +1,37 @@
+package gcode
+
+import (
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+// Path is an ordered, continuous toolpath. Supported elements are
+// primitive.Line, primitive.Arc, primitive.Polygon (cut as a closed loop),
+// primitive.Circle and primitive.Rectangle.
+type Path []primitive.Shape
+
+// Units selects the unit word written to, or read from, a program.
+type Units int
+
+const (
+	Millimeters Units = iota
+	Inches
+)
+
+// Dialect selects the controller flavour of a program.
+type Dialect int
+
+const (
+	GRBL Dialect = iota
+	LinuxCNC
+	Marlin
+)
+
+// PathFromPoints builds a path of straight moves through the points.
+func PathFromPoints(points []vector2.Vector2) Path {
+	path := make(Path, 0, len(points))
+	for i := 1; i < len(points); i++ {
+		path = append(path, primitive.Line{Start: points[i-1], End: points[i]})
+	}
+	return path
+}
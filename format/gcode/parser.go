@@ -0,0 +1,338 @@
+package gcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+	"github.com/anaxarchus/MathEngine/internal/global"
+)
+
+// Program is the geometry reconstructed from a G-code program.
+type Program struct {
+	// Units of all coordinates. It is set by the first G20/G21 word; later
+	// unit changes are converted into it.
+	Units Units
+	// Paths are the continuous runs of G1/G2/G3 moves, split at rapids.
+	Paths []Path
+	// Feeds holds the feed rate of every move in Paths, in Units per minute:
+	// Feeds[i][j] is the rate Paths[i][j] was cut at, or 0 when no F word
+	// had been given yet.
+	Feeds [][]float64
+	// Rapids are the G0 moves projected onto the XY plane.
+	Rapids []primitive.Line
+}
+
+// Shapes returns the cutting geometry as primitives. Consecutive straight
+// moves that end where they started are merged into a closed Polygon; other
+// straight moves stay Line segments, and every G2/G3 move becomes an Arc.
+func (p *Program) Shapes() []primitive.Shape {
+	var shapes []primitive.Shape
+	for _, path := range p.Paths {
+		var run []primitive.Shape
+		flush := func() {
+			if len(run) > 2 && run[0].(primitive.Line).Start.IsEqualApprox(run[len(run)-1].(primitive.Line).End) {
+				loop := make(primitive.Polygon, len(run))
+				for i, s := range run {
+					loop[i] = s.(primitive.Line).Start
+				}
+				shapes = append(shapes, loop)
+			} else {
+				shapes = append(shapes, run...)
+			}
+			run = nil
+		}
+		for _, s := range path {
+			if _, ok := s.(primitive.Line); ok {
+				run = append(run, s)
+				continue
+			}
+			flush()
+			shapes = append(shapes, s)
+		}
+		flush()
+	}
+	return shapes
+}
+
+// parser holds the modal state of the machine while reading a program.
+type parser struct {
+	program   *Program
+	unitsSet  bool
+	scale     float64
+	absolute  bool
+	plane     int
+	motion    int
+	position  vector2.Vector2
+	z         float64
+	feed      float64
+	path      Path
+	feeds     []float64
+	lineIndex int
+}
+
+// Parse reads a G-code program. Comments, line numbers, "*nn" checksums and
+// words other than G, X, Y, Z, I, J, R and F are ignored. A leading "/"
+// block delete character is dropped, so such blocks run as they would with
+// the block delete switch off. Modal G words take effect for the whole block
+// they appear in, wherever they stand in it. Feed rates are read as units
+// per minute.
+func Parse(r io.Reader) (*Program, error) {
+	p := &parser{
+		program:  &Program{},
+		scale:    1,
+		absolute: true,
+		plane:    17,
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.lineIndex++
+		if err := p.parseLine(scanner.Text()); err != nil {
+			return nil, fmt.Errorf("gcode: line %d: %w", p.lineIndex, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	p.flush()
+	return p.program, nil
+}
+
+func (p *parser) parseLine(line string) error {
+	words, err := splitWords(stripChecksum(stripBlockDelete(stripComments(line))))
+	if err != nil {
+		return err
+	}
+
+	// Set the modes of the block first, so that a unit change applies to
+	// every coordinate of its block.
+	motionSet := false
+	for _, w := range words {
+		if w.letter == 'G' {
+			switch w.value {
+			case 0, 1, 2, 3:
+				p.motion = int(w.value)
+				motionSet = true
+			case 17, 18, 19:
+				p.plane = int(w.value)
+			case 20:
+				p.setUnits(Inches)
+			case 21:
+				p.setUnits(Millimeters)
+			case 90:
+				p.absolute = true
+			case 91:
+				p.absolute = false
+			}
+		}
+	}
+	values := map[byte]float64{}
+	for _, w := range words {
+		switch w.letter {
+		case 'X', 'Y', 'Z', 'I', 'J', 'R':
+			values[w.letter] = w.value * p.scale
+		case 'F':
+			p.feed = w.value * p.scale
+		}
+	}
+
+	_, hasX := values['X']
+	_, hasY := values['Y']
+	_, hasZ := values['Z']
+	if !hasX && !hasY && !motionSet {
+		if hasZ {
+			p.moveZ(values['Z'])
+		}
+		return nil
+	}
+
+	target := p.position
+	if p.absolute {
+		if hasX {
+			target.X = values['X']
+		}
+		if hasY {
+			target.Y = values['Y']
+		}
+	} else {
+		target = target.Add(vector2.Vector2{X: values['X'], Y: values['Y']})
+	}
+	if hasZ {
+		p.moveZ(values['Z'])
+	}
+
+	switch p.motion {
+	case 0:
+		p.flush()
+		if !p.position.IsEqualApprox(target) {
+			p.program.Rapids = append(p.program.Rapids, primitive.Line{Start: p.position, End: target})
+		}
+	case 1:
+		if !p.position.IsEqualApprox(target) {
+			p.path = append(p.path, primitive.Line{Start: p.position, End: target})
+			p.feeds = append(p.feeds, p.feed)
+		}
+	case 2, 3:
+		if p.plane != 17 {
+			return fmt.Errorf("arcs outside the XY plane (G%d) are not supported", p.plane)
+		}
+		arc, err := p.arc(target, values, p.motion == 2)
+		if err != nil {
+			return err
+		}
+		p.path = append(p.path, arc)
+		p.feeds = append(p.feeds, p.feed)
+	}
+	p.position = target
+	return nil
+}
+
+// arc builds the Arc for a G2 (clockwise) or G3 move from the current
+// position to target, from either I/J centre offsets or an R radius.
+func (p *parser) arc(target vector2.Vector2, values map[byte]float64, clockwise bool) (primitive.Arc, error) {
+	start := p.position
+	var center vector2.Vector2
+	if r, ok := values['R']; ok {
+		chord := target.Sub(start)
+		d := chord.Length()
+		if d == 0 || math.Abs(r) < d/2-global.EPSILON {
+			return primitive.Arc{}, fmt.Errorf("invalid arc radius %g", r)
+		}
+		h := math.Sqrt(math.Max(r*r-d*d/4, 0))
+		normal := vector2.Vector2{X: -chord.Y, Y: chord.X}.Divf(d)
+		// A positive R selects the short arc, a negative one the long arc.
+		if clockwise == (r > 0) {
+			h = -h
+		}
+		center = start.Add(chord.Mulf(0.5)).Add(normal.Mulf(h))
+	} else {
+		center = start.Add(vector2.Vector2{X: values['I'], Y: values['J']})
+	}
+
+	radius := center.DistanceTo(start)
+	if radius < global.EPSILON {
+		return primitive.Arc{}, fmt.Errorf("arc without a centre or radius")
+	}
+
+	angleStart := center.AngleToPoint(start)
+	sweep := center.AngleToPoint(target) - angleStart
+	if clockwise {
+		for sweep >= 0 {
+			sweep -= global.TAU
+		}
+	} else {
+		for sweep <= 0 {
+			sweep += global.TAU
+		}
+	}
+	return primitive.Arc{
+		Circle:     primitive.Circle{Center: center, Radius: radius},
+		AngleStart: angleStart,
+		AngleEnd:   angleStart + sweep,
+	}, nil
+}
+
+func (p *parser) moveZ(z float64) {
+	if p.absolute {
+		p.z = z
+	} else {
+		p.z += z
+	}
+}
+
+func (p *parser) setUnits(u Units) {
+	if !p.unitsSet {
+		p.program.Units = u
+		p.unitsSet = true
+	}
+	p.scale = 1
+	if u == Inches && p.program.Units == Millimeters {
+		p.scale = 25.4
+	} else if u == Millimeters && p.program.Units == Inches {
+		p.scale = 1 / 25.4
+	}
+}
+
+func (p *parser) flush() {
+	if len(p.path) > 0 {
+		p.program.Paths = append(p.program.Paths, p.path)
+		p.program.Feeds = append(p.program.Feeds, p.feeds)
+		p.path = nil
+		p.feeds = nil
+	}
+}
+
+type word struct {
+	letter byte
+	value  float64
+}
+
+func stripComments(line string) string {
+	if i := strings.IndexByte(line, ';'); i >= 0 {
+		line = line[:i]
+	}
+	var b strings.Builder
+	depth := 0
+	for _, r := range line {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// stripBlockDelete drops a leading "/" block delete character and the
+// optional switch number after it.
+func stripBlockDelete(line string) string {
+	line = strings.TrimLeftFunc(line, unicode.IsSpace)
+	if !strings.HasPrefix(line, "/") {
+		return line
+	}
+	return strings.TrimLeft(line[1:], "0123456789")
+}
+
+// stripChecksum drops a "*nn" checksum, which ends the block.
+func stripChecksum(line string) string {
+	if i := strings.IndexByte(line, '*'); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+func splitWords(line string) ([]word, error) {
+	var words []word
+	line = strings.ToUpper(line)
+	for i := 0; i < len(line); {
+		c := line[i]
+		if unicode.IsSpace(rune(c)) || c == '%' {
+			i++
+			continue
+		}
+		if c < 'A' || c > 'Z' {
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+		j := i + 1
+		for j < len(line) && (line[j] == '.' || line[j] == '-' || line[j] == '+' || line[j] == ' ' || (line[j] >= '0' && line[j] <= '9')) {
+			j++
+		}
+		number := strings.ReplaceAll(line[i+1:j], " ", "")
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q for %c", number, c)
+		}
+		words = append(words, word{letter: c, value: value})
+		i = j
+	}
+	return words, nil
+}
@@ -0,0 +1,171 @@
+package gcode
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+func near(a, b vector2.Vector2, tolerance float64) bool {
+	return a.DistanceTo(b) <= tolerance
+}
+
+func TestParseClockwiseArcPolygonizes(t *testing.T) {
+	program, err := Parse(strings.NewReader("G0 X10 Y0\nG2 X0 Y-10 I-10 J0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(program.Paths) != 1 || len(program.Paths[0]) != 1 {
+		t.Fatalf("got paths %v, want one arc", program.Paths)
+	}
+	arc, ok := program.Paths[0][0].(primitive.Arc)
+	if !ok {
+		t.Fatalf("got %T, want primitive.Arc", program.Paths[0][0])
+	}
+	if math.Abs(arc.Sweep()+math.Pi/2) > 1e-9 {
+		t.Errorf("sweep = %g, want %g", arc.Sweep(), -math.Pi/2)
+	}
+
+	points := primitive.Polygonize(arc, 0.01)
+	want := vector2.Vector2{X: 10 / math.Sqrt2, Y: -10 / math.Sqrt2}
+	if mid := points[len(points)/2]; !near(mid, want, 1e-9) {
+		t.Errorf("midpoint = %v, want %v", mid, want)
+	}
+	for _, p := range points {
+		if p.X < -1e-9 || p.Y > 1e-9 {
+			t.Fatalf("point %v lies outside the clockwise quarter", p)
+		}
+	}
+}
+
+func TestWriteParseRoundTrip(t *testing.T) {
+	path := Path{
+		primitive.Line{Start: vector2.Vector2{X: 0, Y: 0}, End: vector2.Vector2{X: 10, Y: 0}},
+		primitive.NewArc(10, 5, 5, -math.Pi/2, math.Pi/2),
+		primitive.NewArc(10, 15, 5, -math.Pi/2, -3*math.Pi/2),
+	}
+	opts := DefaultOptions(GRBL)
+	opts.Precision = 6
+	var b strings.Builder
+	if err := Write(&b, []Path{path}, opts); err != nil {
+		t.Fatal(err)
+	}
+	program, err := Parse(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(program.Paths) != 1 {
+		t.Fatalf("got %d paths, want 1", len(program.Paths))
+	}
+	got := program.Paths[0]
+	if len(got) != len(path) {
+		t.Fatalf("got %d moves, want %d:\n%s", len(got), len(path), b.String())
+	}
+	for i, feed := range program.Feeds[0] {
+		if feed != opts.FeedRate {
+			t.Errorf("move %d feed = %g, want %g", i, feed, opts.FeedRate)
+		}
+	}
+	line, ok := got[0].(primitive.Line)
+	if !ok || !near(line.Start, path[0].(primitive.Line).Start, 1e-6) || !near(line.End, path[0].(primitive.Line).End, 1e-6) {
+		t.Errorf("move 0 = %v, want %v", got[0], path[0])
+	}
+	for i := 1; i < len(path); i++ {
+		want := path[i].(primitive.Arc)
+		arc, ok := got[i].(primitive.Arc)
+		if !ok {
+			t.Fatalf("move %d is %T, want primitive.Arc", i, got[i])
+		}
+		if !near(arc.Circle.Center, want.Circle.Center, 1e-6) || math.Abs(arc.Circle.Radius-want.Circle.Radius) > 1e-6 ||
+			math.Abs(arc.Sweep()-want.Sweep()) > 1e-6 || !near(arc.StartPoint(), want.StartPoint(), 1e-6) {
+			t.Errorf("move %d = %+v, want %+v", i, arc, want)
+		}
+	}
+}
+
+func TestParseFeeds(t *testing.T) {
+	src := "G20\nG0 X0 Y0\nG1 X1 F10\nG1 Y1\nG2 X2 Y0 R1 F20\nG0 X5\nG1 X6\n"
+	program, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]float64{{10, 10, 20}, {20}}
+	if len(program.Feeds) != len(want) {
+		t.Fatalf("got feeds %v, want %v", program.Feeds, want)
+	}
+	for i := range want {
+		if len(program.Feeds[i]) != len(program.Paths[i]) || len(program.Feeds[i]) != len(want[i]) {
+			t.Fatalf("got feeds %v for paths %v, want %v", program.Feeds, program.Paths, want)
+		}
+		for j := range want[i] {
+			if program.Feeds[i][j] != want[i][j] {
+				t.Errorf("feed %d/%d = %g, want %g", i, j, program.Feeds[i][j], want[i][j])
+			}
+		}
+	}
+
+	program, err = Parse(strings.NewReader("G21\nG1 X1 F100\nG20\nG1 X2 F1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := program.Feeds[0]; got[0] != 100 || got[1] != 25.4 {
+		t.Errorf("feeds = %v, want [100 25.4]", got)
+	}
+}
+
+func TestShapesKeepsOpenRunsOpen(t *testing.T) {
+	src := "G0 X0 Y0\nG1 X10\nG1 Y10\nG1 X0\nG1 Y0\nG0 X20 Y0\nG1 X30\nG1 Y10\n"
+	program, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shapes := program.Shapes()
+	if len(shapes) != 3 {
+		t.Fatalf("got %d shapes %v, want a loop and two lines", len(shapes), shapes)
+	}
+	loop, ok := shapes[0].(primitive.Polygon)
+	if !ok || len(loop) != 4 || loop.Properties().SignedArea != 100 {
+		t.Errorf("shape 0 = %v, want the closed square", shapes[0])
+	}
+	for _, s := range shapes[1:] {
+		if _, ok := s.(primitive.Line); !ok {
+			t.Errorf("got %T, want primitive.Line for an open run", s)
+		}
+	}
+}
+
+func TestParseModalWordsApplyToTheirBlock(t *testing.T) {
+	for _, src := range []string{
+		"G21\nG0 X0 Y0\nG1 X1 G20\n",
+		"G21\nG0 X0 Y0\nG20 G1 X1\n",
+	} {
+		program, err := Parse(strings.NewReader(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		line, ok := program.Paths[0][0].(primitive.Line)
+		if !ok || line.End.X != 25.4 {
+			t.Errorf("%q: got %v, want a move to X25.4", src, program.Paths[0][0])
+		}
+	}
+}
+
+func TestParseChecksumsAndBlockDelete(t *testing.T) {
+	src := "N1 G0 X0 Y0*87\n/N2 G1 X10*12\n /1 G1 Y10\nN4 G1 X0 (to *start)*5\n"
+	program, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []vector2.Vector2{{X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	if len(program.Paths) != 1 || len(program.Paths[0]) != len(want) {
+		t.Fatalf("got paths %v, want moves to %v", program.Paths, want)
+	}
+	for i, s := range program.Paths[0] {
+		if line, ok := s.(primitive.Line); !ok || line.End != want[i] {
+			t.Errorf("move %d = %v, want a line to %v", i, s, want[i])
+		}
+	}
+}
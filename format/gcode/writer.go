@@ -0,0 +1,267 @@
+package gcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+	"github.com/anaxarchus/MathEngine/internal/global"
+)
+
+// Options configures a Writer. Coordinates are written as given; Units only
+// selects the G20/G21 word.
+type Options struct {
+	Dialect      Dialect
+	Units        Units
+	Precision    int
+	FeedRate     float64
+	PlungeRate   float64
+	SpindleSpeed float64
+	// SafeHeight is the Z height for rapid moves between paths.
+	SafeHeight float64
+	// CutDepth is the Z height while cutting.
+	CutDepth float64
+	// Header and Footer are text/template sources that override the dialect
+	// defaults. They are executed with the Options as data, extended with
+	// UnitsWord and a Format function.
+	Header, Footer string
+}
+
+// DefaultOptions returns millimeter options with the dialect's templates.
+func DefaultOptions(d Dialect) Options {
+	return Options{
+		Dialect:      d,
+		Units:        Millimeters,
+		Precision:    4,
+		FeedRate:     600,
+		PlungeRate:   200,
+		SpindleSpeed: 10000,
+		SafeHeight:   5,
+		CutDepth:     -1,
+	}
+}
+
+var dialectHeaders = map[Dialect]string{
+	GRBL:     "{{.UnitsWord}} G90 G17 G94\nM3 S{{Format .SpindleSpeed}}\nG0 Z{{Format .SafeHeight}}\n",
+	LinuxCNC: "%\n{{.UnitsWord}} G90 G17 G94 G40 G49 G64 P0.01\nM3 S{{Format .SpindleSpeed}}\nG0 Z{{Format .SafeHeight}}\n",
+	Marlin:   "{{.UnitsWord}}\nG90\nM3 S{{Format .SpindleSpeed}}\nG0 Z{{Format .SafeHeight}}\n",
+}
+
+var dialectFooters = map[Dialect]string{
+	GRBL:     "G0 Z{{Format .SafeHeight}}\nM5\nM2\n",
+	LinuxCNC: "G0 Z{{Format .SafeHeight}}\nM5\nM2\n%\n",
+	Marlin:   "G0 Z{{Format .SafeHeight}}\nM5\nM84\n",
+}
+
+// Writer emits G0/G1/G2/G3 programs from toolpaths.
+type Writer struct {
+	w        *bufio.Writer
+	opts     Options
+	position vector2.Vector2
+	z        float64
+	feed     float64
+	started  bool
+}
+
+func NewWriter(w io.Writer, opts Options) *Writer {
+	return &Writer{
+		w:    bufio.NewWriter(w),
+		opts: opts,
+		z:    math.NaN(),
+		feed: math.NaN(),
+	}
+}
+
+// Write emits a complete program with header, paths and footer.
+func Write(w io.Writer, paths []Path, opts Options) error {
+	g := NewWriter(w, opts)
+	if err := g.WriteHeader(); err != nil {
+		return err
+	}
+	for _, p := range paths {
+		if err := g.WritePath(p); err != nil {
+			return err
+		}
+	}
+	return g.WriteFooter()
+}
+
+func (g *Writer) WriteHeader() error {
+	src := g.opts.Header
+	if src == "" {
+		src = dialectHeaders[g.opts.Dialect]
+	}
+	if err := g.execute("header", src); err != nil {
+		return err
+	}
+	g.z = g.opts.SafeHeight
+	return g.w.Flush()
+}
+
+func (g *Writer) WriteFooter() error {
+	src := g.opts.Footer
+	if src == "" {
+		src = dialectFooters[g.opts.Dialect]
+	}
+	if err := g.execute("footer", src); err != nil {
+		return err
+	}
+	return g.w.Flush()
+}
+
+// WritePath retracts, rapids to the start of the path, plunges and cuts every
+// element in order. Gaps between elements are bridged with straight cuts.
+func (g *Writer) WritePath(p Path) error {
+	if len(p) == 0 {
+		return nil
+	}
+	start, ok := startPoint(p[0])
+	if !ok {
+		return fmt.Errorf("gcode: unsupported shape %T", p[0])
+	}
+
+	g.retract()
+	if !g.started || !g.position.IsEqualApprox(start) {
+		g.line("G0 X%s Y%s", g.format(start.X), g.format(start.Y))
+		g.position = start
+		g.started = true
+	}
+	g.line("G1 Z%s%s", g.format(g.opts.CutDepth), g.feedWord(g.opts.PlungeRate))
+	g.z = g.opts.CutDepth
+
+	for _, s := range p {
+		if err := g.cut(s); err != nil {
+			return err
+		}
+	}
+	g.retract()
+	return g.w.Flush()
+}
+
+func (g *Writer) cut(s primitive.Shape) error {
+	switch s := s.(type) {
+	case primitive.Line:
+		g.lineTo(s.Start)
+		g.lineTo(s.End)
+	case primitive.Polygon:
+		if len(s) == 0 {
+			return nil
+		}
+		for _, v := range s {
+			g.lineTo(v)
+		}
+		g.lineTo(s[0])
+	case primitive.Rectangle:
+		return g.cut(primitive.Polygonize(s, 0))
+	case primitive.Arc:
+		g.arc(s)
+	case primitive.Circle:
+		g.arc(primitive.Arc{Circle: s, AngleStart: 0, AngleEnd: 2 * math.Pi})
+	default:
+		return fmt.Errorf("gcode: unsupported shape %T", s)
+	}
+	return nil
+}
+
+func (g *Writer) lineTo(v vector2.Vector2) {
+	if g.position.IsEqualApprox(v) {
+		return
+	}
+	g.line("G1 X%s Y%s%s", g.format(v.X), g.format(v.Y), g.feedWord(g.opts.FeedRate))
+	g.position = v
+}
+
+// arc emits G2 or G3 moves with I/J centre offsets. Full circles and longer
+// sweeps are split so that no single move ends where it starts.
+func (g *Writer) arc(a primitive.Arc) {
+	g.lineTo(a.StartPoint())
+	steps := int(math.Ceil(math.Abs(a.Sweep()) / global.PI))
+	if steps < 1 {
+		steps = 1
+	}
+	step := a.Sweep() / float64(steps)
+	for i := 0; i < steps; i++ {
+		part := a
+		part.AngleStart = a.AngleStart + float64(i)*step
+		part.AngleEnd = part.AngleStart + step
+		end := part.EndPoint()
+		word := "G3"
+		if part.Direction() == 1 {
+			word = "G2"
+		}
+		offset := a.Circle.Center.Sub(g.position)
+		g.line("%s X%s Y%s I%s J%s%s", word, g.format(end.X), g.format(end.Y),
+			g.format(offset.X), g.format(offset.Y), g.feedWord(g.opts.FeedRate))
+		g.position = end
+	}
+}
+
+func (g *Writer) retract() {
+	if g.z != g.opts.SafeHeight {
+		g.line("G0 Z%s", g.format(g.opts.SafeHeight))
+		g.z = g.opts.SafeHeight
+	}
+}
+
+func (g *Writer) feedWord(feed float64) string {
+	if feed <= 0 || feed == g.feed {
+		return ""
+	}
+	g.feed = feed
+	return " F" + g.format(feed)
+}
+
+func (g *Writer) line(format string, args ...interface{}) {
+	fmt.Fprintf(g.w, format+"\n", args...)
+}
+
+func (g *Writer) format(v float64) string {
+	s := strconv.FormatFloat(v, 'f', g.opts.Precision, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
+
+func (g *Writer) execute(name, src string) error {
+	t, err := template.New(name).Funcs(template.FuncMap{"Format": g.format}).Parse(src)
+	if err != nil {
+		return err
+	}
+	unitsWord := "G21"
+	if g.opts.Units == Inches {
+		unitsWord = "G20"
+	}
+	data := struct {
+		Options
+		UnitsWord string
+	}{g.opts, unitsWord}
+	return t.Execute(g.w, data)
+}
+
+func startPoint(s primitive.Shape) (vector2.Vector2, bool) {
+	switch s := s.(type) {
+	case primitive.Line:
+		return s.Start, true
+	case primitive.Polygon:
+		if len(s) > 0 {
+			return s[0], true
+		}
+	case primitive.Rectangle:
+		return s.Offset, true
+	case primitive.Arc:
+		return s.StartPoint(), true
+	case primitive.Circle:
+		return primitive.Arc{Circle: s}.StartPoint(), true
+	}
+	return vector2.Vector2{}, false
+}
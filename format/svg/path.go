@@ -0,0 +1,407 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+// maxFlattenDepth bounds the subdivision of a single Bezier segment.
+const maxFlattenDepth = 16
+
+// segment is a single drawing command of a path in absolute coordinates.
+// Quadratic curves are stored as cubics.
+type segment struct {
+	kind     byte // 'L', 'C' or 'A'
+	from, to vector2.Vector2
+	c1, c2   vector2.Vector2
+	// Elliptical arc parameters.
+	rx, ry, phi  float64
+	large, sweep bool
+}
+
+type subpath struct {
+	segments []segment
+	closed   bool
+}
+
+// parsePathData parses the d attribute of a <path> into absolute subpaths.
+func parsePathData(d string) ([]subpath, error) {
+	t := &pathTokenizer{s: d}
+	var paths []subpath
+	current := -1
+	var pos, start, lastControl vector2.Vector2
+	var lastCmd byte
+
+	for {
+		cmd, ok := t.command(lastCmd)
+		if !ok {
+			break
+		}
+		relative := cmd >= 'a'
+		upper := cmd &^ 0x20
+		base := vector2.Vector2{}
+		if relative {
+			base = pos
+		}
+		point := func() (vector2.Vector2, error) {
+			x, err := t.number()
+			if err != nil {
+				return vector2.Vector2{}, err
+			}
+			y, err := t.number()
+			if err != nil {
+				return vector2.Vector2{}, err
+			}
+			return base.Add(vector2.Vector2{X: x, Y: y}), nil
+		}
+		ensure := func() {
+			if current < 0 {
+				paths = append(paths, subpath{})
+				current = len(paths) - 1
+				start = pos
+			}
+		}
+
+		var err error
+		switch upper {
+		case 'M':
+			var p vector2.Vector2
+			if p, err = point(); err != nil {
+				return nil, err
+			}
+			pos, start = p, p
+			paths = append(paths, subpath{})
+			current = len(paths) - 1
+			// Further coordinate pairs are implicit line-tos.
+			cmd = 'L' | (cmd & 0x20)
+		case 'L', 'H', 'V':
+			ensure()
+			to := pos
+			switch upper {
+			case 'L':
+				to, err = point()
+			case 'H':
+				var x float64
+				x, err = t.number()
+				to.X = base.X + x
+			case 'V':
+				var y float64
+				y, err = t.number()
+				to.Y = base.Y + y
+			}
+			if err != nil {
+				return nil, err
+			}
+			paths[current].segments = append(paths[current].segments, segment{kind: 'L', from: pos, to: to})
+			pos = to
+		case 'C', 'S', 'Q', 'T':
+			ensure()
+			var c1, c2, to vector2.Vector2
+			reflected := pos
+			previous := lastCmd | 0x20
+			if (upper == 'S' && (previous == 'c' || previous == 's')) ||
+				(upper == 'T' && (previous == 'q' || previous == 't')) {
+				reflected = pos.Mulf(2).Sub(lastControl)
+			}
+			switch upper {
+			case 'C':
+				if c1, err = point(); err == nil {
+					if c2, err = point(); err == nil {
+						to, err = point()
+					}
+				}
+				lastControl = c2
+			case 'S':
+				c1 = reflected
+				if c2, err = point(); err == nil {
+					to, err = point()
+				}
+				lastControl = c2
+			case 'Q', 'T':
+				q := reflected
+				if upper == 'Q' {
+					q, err = point()
+				}
+				if err == nil {
+					to, err = point()
+				}
+				lastControl = q
+				c1 = pos.Add(q.Sub(pos).Mulf(2.0 / 3.0))
+				c2 = to.Add(q.Sub(to).Mulf(2.0 / 3.0))
+			}
+			if err != nil {
+				return nil, err
+			}
+			paths[current].segments = append(paths[current].segments, segment{kind: 'C', from: pos, c1: c1, c2: c2, to: to})
+			pos = to
+		case 'A':
+			ensure()
+			var rx, ry, phi float64
+			var large, sweep bool
+			if rx, err = t.number(); err == nil {
+				if ry, err = t.number(); err == nil {
+					if phi, err = t.number(); err == nil {
+						if large, err = t.flag(); err == nil {
+							sweep, err = t.flag()
+						}
+					}
+				}
+			}
+			var to vector2.Vector2
+			if err == nil {
+				to, err = point()
+			}
+			if err != nil {
+				return nil, err
+			}
+			// An arc to the current point is omitted, and one with a zero
+			// radius is a straight line, as the SVG implementation notes
+			// require.
+			if to == pos {
+				break
+			}
+			if rx == 0 || ry == 0 {
+				paths[current].segments = append(paths[current].segments, segment{kind: 'L', from: pos, to: to})
+				pos = to
+				break
+			}
+			paths[current].segments = append(paths[current].segments, segment{
+				kind: 'A', from: pos, to: to,
+				rx: math.Abs(rx), ry: math.Abs(ry), phi: phi * math.Pi / 180,
+				large: large, sweep: sweep,
+			})
+			pos = to
+		case 'Z':
+			if current >= 0 {
+				paths[current].closed = true
+				current = -1
+			}
+			pos = start
+		default:
+			return nil, fmt.Errorf("svg: unknown path command %q", cmd)
+		}
+		lastCmd = cmd
+	}
+	if !t.done() {
+		return nil, fmt.Errorf("svg: invalid path data near %q", t.rest())
+	}
+	return paths, nil
+}
+
+// arcCenter converts an endpoint arc to its center parameterisation, as
+// described in the SVG implementation notes. Radii that are too small to span
+// the endpoints are scaled up. The parser drops arcs whose endpoints
+// coincide and turns arcs with a zero radius into lines, so neither reaches
+// here.
+func (s segment) arcCenter() (center vector2.Vector2, rx, ry, theta, delta float64) {
+	rx, ry = s.rx, s.ry
+	cosPhi, sinPhi := math.Cos(s.phi), math.Sin(s.phi)
+	dx, dy := (s.from.X-s.to.X)/2, (s.from.Y-s.to.Y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(num/den, 0))
+	if s.large == s.sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+	center = vector2.Vector2{
+		X: cosPhi*cx1 - sinPhi*cy1 + (s.from.X+s.to.X)/2,
+		Y: sinPhi*cx1 + cosPhi*cy1 + (s.from.Y+s.to.Y)/2,
+	}
+
+	u := vector2.Vector2{X: (x1 - cx1) / rx, Y: (y1 - cy1) / ry}
+	v := vector2.Vector2{X: (-x1 - cx1) / rx, Y: (-y1 - cy1) / ry}
+	theta = math.Atan2(u.Y, u.X)
+	delta = math.Atan2(u.Cross(v), u.Dot(v))
+	if !s.sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if s.sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+	return center, rx, ry, theta, delta
+}
+
+// circularArc returns the segment as a primitive Arc under the transform when
+// both are circular.
+func (s segment) circularArc(m matrix) (primitive.Arc, bool) {
+	if s.kind != 'A' || s.rx == 0 || s.ry == 0 || !m.isSimilarity() {
+		return primitive.Arc{}, false
+	}
+	center, rx, ry, _, delta := s.arcCenter()
+	if math.Abs(rx-ry) > 1e-9*math.Max(rx, 1) {
+		return primitive.Arc{}, false
+	}
+	c := m.apply(center)
+	start := c.AngleToPoint(m.apply(s.from))
+	if m.det() < 0 {
+		delta = -delta
+	}
+	return primitive.Arc{
+		Circle:     primitive.Circle{Center: c, Radius: rx * m.scale()},
+		AngleStart: start,
+		AngleEnd:   start + delta,
+	}, true
+}
+
+// flatten appends points approximating the segment, excluding its start, in
+// untransformed coordinates.
+func (s segment) flatten(points []vector2.Vector2, tolerance float64) []vector2.Vector2 {
+	switch s.kind {
+	case 'C':
+		return flattenCubic(points, s.from, s.c1, s.c2, s.to, tolerance, 0)
+	case 'A':
+		if s.rx == 0 || s.ry == 0 {
+			return append(points, s.to)
+		}
+		center, rx, ry, theta, delta := s.arcCenter()
+		r := math.Max(rx, ry)
+		step := math.Pi / 2
+		if tolerance < r {
+			step = 2 * math.Acos(1-tolerance/r)
+		}
+		n := int(math.Ceil(math.Abs(delta) / step))
+		if n < 1 {
+			n = 1
+		}
+		cosPhi, sinPhi := math.Cos(s.phi), math.Sin(s.phi)
+		for i := 1; i < n; i++ {
+			a := theta + delta*float64(i)/float64(n)
+			x, y := rx*math.Cos(a), ry*math.Sin(a)
+			points = append(points, vector2.Vector2{
+				X: center.X + cosPhi*x - sinPhi*y,
+				Y: center.Y + sinPhi*x + cosPhi*y,
+			})
+		}
+		return append(points, s.to)
+	}
+	return append(points, s.to)
+}
+
+// flattenCubic subdivides the curve until its control points lie within
+// tolerance of the chord.
+func flattenCubic(points []vector2.Vector2, p0, p1, p2, p3 vector2.Vector2, tolerance float64, depth int) []vector2.Vector2 {
+	chord := [2]vector2.Vector2{p0, p3}
+	if depth >= maxFlattenDepth || (lineDistance(p1, chord) <= tolerance && lineDistance(p2, chord) <= tolerance) {
+		return append(points, p3)
+	}
+	p01 := p0.Add(p1).Mulf(0.5)
+	p12 := p1.Add(p2).Mulf(0.5)
+	p23 := p2.Add(p3).Mulf(0.5)
+	p012 := p01.Add(p12).Mulf(0.5)
+	p123 := p12.Add(p23).Mulf(0.5)
+	mid := p012.Add(p123).Mulf(0.5)
+	points = flattenCubic(points, p0, p01, p012, mid, tolerance, depth+1)
+	return flattenCubic(points, mid, p123, p23, p3, tolerance, depth+1)
+}
+
+func lineDistance(p vector2.Vector2, line [2]vector2.Vector2) float64 {
+	d := line[1].Sub(line[0])
+	if l := d.Length(); l > 0 {
+		return math.Abs(d.Cross(p.Sub(line[0]))) / l
+	}
+	return p.DistanceTo(line[0])
+}
+
+type pathTokenizer struct {
+	s string
+	i int
+}
+
+func (t *pathTokenizer) skip() {
+	for t.i < len(t.s) && strings.IndexByte(" \t\r\n,", t.s[t.i]) >= 0 {
+		t.i++
+	}
+}
+
+func (t *pathTokenizer) done() bool {
+	t.skip()
+	return t.i >= len(t.s)
+}
+
+func (t *pathTokenizer) rest() string {
+	return t.s[t.i:]
+}
+
+// command returns the next command letter, or repeats the previous command
+// when the data continues with numbers.
+func (t *pathTokenizer) command(last byte) (byte, bool) {
+	t.skip()
+	if t.i >= len(t.s) {
+		return 0, false
+	}
+	c := t.s[t.i]
+	if strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0 {
+		t.i++
+		return c, true
+	}
+	if last != 0 && last|0x20 != 'z' {
+		return last, true
+	}
+	return 0, false
+}
+
+func (t *pathTokenizer) number() (float64, error) {
+	t.skip()
+	start := t.i
+	if t.i < len(t.s) && (t.s[t.i] == '+' || t.s[t.i] == '-') {
+		t.i++
+	}
+	dot := false
+	for t.i < len(t.s) {
+		c := t.s[t.i]
+		if c >= '0' && c <= '9' {
+			t.i++
+		} else if c == '.' && !dot {
+			dot = true
+			t.i++
+		} else if (c == 'e' || c == 'E') && t.i > start {
+			t.i++
+			if t.i < len(t.s) && (t.s[t.i] == '+' || t.s[t.i] == '-') {
+				t.i++
+			}
+		} else {
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(t.s[start:t.i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("svg: invalid number in path data near %q", t.s[start:])
+	}
+	return v, nil
+}
+
+func (t *pathTokenizer) flag() (bool, error) {
+	t.skip()
+	if t.i < len(t.s) && (t.s[t.i] == '0' || t.s[t.i] == '1') {
+		t.i++
+		return t.s[t.i-1] == '1', nil
+	}
+	return false, fmt.Errorf("svg: invalid arc flag near %q", t.rest())
+}
+
+// parseNumbers parses a list of numbers separated by whitespace or commas.
+func parseNumbers(s string) ([]float64, error) {
+	t := &pathTokenizer{s: s}
+	var values []float64
+	for !t.done() {
+		v, err := t.number()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,418 @@
+package svg
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+// skippedElements hold definitions rather than rendered content.
+var skippedElements = map[string]bool{
+	"defs": true, "clipPath": true, "mask": true, "marker": true,
+	"pattern": true, "symbol": true, "style": true, "metadata": true,
+}
+
+// presentation holds the inheritable styling attributes of an element.
+type presentation struct {
+	fill, stroke, fillRule              string
+	strokeWidth                         float64
+	dash                                []float64
+	fillOpacity, strokeOpacity, opacity float64
+}
+
+type frame struct {
+	transform matrix
+	style     presentation
+	group     *Group
+}
+
+type reader struct {
+	doc       *Document
+	tolerance float64
+}
+
+// Decode reads an SVG document. Paths and basic shapes are converted to
+// primitives with nested transforms applied: circles and ellipses stay exact,
+// circular arcs stay exact where the transform allows it, and other curves are
+// flattened to polygons that stay within tolerance of the true outline. Groups
+// and fill and stroke styling are kept.
+func Decode(r io.Reader, tolerance float64) (*Document, error) {
+	if tolerance <= 0 {
+		tolerance = primitive.DefaultTolerance
+	}
+	rd := &reader{doc: &Document{}, tolerance: tolerance}
+	decoder := xml.NewDecoder(r)
+	stack := []frame{{
+		transform: identity,
+		style:     presentation{fill: "black", stroke: "none", strokeWidth: 1, fillOpacity: 1, strokeOpacity: 1, opacity: 1},
+		group:     &rd.doc.Group,
+	}}
+	skip := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skip > 0 || skippedElements[t.Name.Local] {
+				skip++
+				continue
+			}
+			parent := stack[len(stack)-1]
+			attrs := attributeMap(t.Attr)
+			f, err := rd.enter(parent, t.Name.Local, attrs)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, f)
+		case xml.EndElement:
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return rd.doc, nil
+}
+
+// enter applies an element's transform and style on top of its parent and
+// converts it when it is a shape.
+func (rd *reader) enter(parent frame, name string, attrs map[string]string) (frame, error) {
+	f := parent
+	if t, ok := attrs["transform"]; ok {
+		m, err := parseTransform(t)
+		if err != nil {
+			return f, err
+		}
+		f.transform = parent.transform.mul(m)
+	}
+	f.style = parent.style.inherit(attrs)
+
+	switch name {
+	case "svg":
+		if parent.group == &rd.doc.Group && rd.doc.Width == 0 {
+			rd.doc.Width = length(attrs["width"])
+			rd.doc.Height = length(attrs["height"])
+		}
+	case "g":
+		f.group = parent.group.NewGroup(attrs["id"])
+	default:
+		elements, err := rd.shape(name, attrs, f.transform, rd.tolerance, f.style.rule())
+		if err != nil {
+			return f, err
+		}
+		style := f.style.resolve(f.transform)
+		for _, e := range elements {
+			e.Style = style
+			parent.group.Elements = append(parent.group.Elements, e)
+		}
+	}
+	return f, nil
+}
+
+func (rd *reader) shape(name string, attrs map[string]string, m matrix, tolerance float64, rule primitive.FillRule) ([]Element, error) {
+	num := func(key string) float64 { return length(attrs[key]) }
+	// Flatten in local coordinates with the tolerance mapped through the
+	// largest stretch of the transform.
+	stretch := math.Max(math.Hypot(m[0], m[1]), math.Hypot(m[2], m[3]))
+	if stretch > 0 {
+		tolerance /= stretch
+	}
+
+	switch name {
+	case "path":
+		subpaths, err := parsePathData(attrs["d"])
+		if err != nil {
+			return nil, err
+		}
+		return pathElements(subpaths, m, tolerance, rule), nil
+	case "rect":
+		x, y, w, h := num("x"), num("y"), num("width"), num("height")
+		rx, ry := num("rx"), num("ry")
+		if rx == 0 {
+			rx = ry
+		}
+		if ry == 0 {
+			ry = rx
+		}
+		if w <= 0 || h <= 0 {
+			return nil, nil
+		}
+		if rx == 0 && m.isAxisAligned() {
+			a, b := m.apply(vector2.New(x, y)), m.apply(vector2.New(x+w, y+h))
+			r := primitive.NewRectangle(math.Min(a.X, b.X), math.Min(a.Y, b.Y), math.Abs(b.X-a.X), math.Abs(b.Y-a.Y))
+			return []Element{{Shape: r}}, nil
+		}
+		rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+		d := fmt.Sprintf("M%g %g H%g A%g %g 0 0 1 %g %g V%g A%g %g 0 0 1 %g %g H%g A%g %g 0 0 1 %g %g V%g A%g %g 0 0 1 %g %g Z",
+			x+rx, y, x+w-rx, rx, ry, x+w, y+ry, y+h-ry, rx, ry, x+w-rx, y+h, x+rx, rx, ry, x, y+h-ry, y+ry, rx, ry, x+rx, y)
+		subpaths, err := parsePathData(d)
+		if err != nil {
+			return nil, err
+		}
+		return pathElements(subpaths, m, tolerance, rule), nil
+	case "circle", "ellipse":
+		cx, cy := num("cx"), num("cy")
+		rx, ry := num("r"), num("r")
+		if name == "ellipse" {
+			rx, ry = num("rx"), num("ry")
+		}
+		if rx <= 0 || ry <= 0 {
+			return nil, nil
+		}
+		affine := algebra.NewAffine(m[0], m[1], m[2], m[3], m[4], m[5])
+		if rx == ry {
+			return []Element{{Shape: primitive.NewCircle(cx, cy, rx).Transform(affine)}}, nil
+		}
+		return []Element{{Shape: primitive.NewEllipse(cx, cy, rx, ry, 0).Transform(affine)}}, nil
+	case "line":
+		a := m.apply(vector2.New(num("x1"), num("y1")))
+		b := m.apply(vector2.New(num("x2"), num("y2")))
+		return []Element{{Shape: primitive.Line{Start: a, End: b}}}, nil
+	case "polygon", "polyline":
+		values, err := parseNumbers(attrs["points"])
+		if err != nil {
+			return nil, err
+		}
+		var p primitive.Polygon
+		for i := 0; i+1 < len(values); i += 2 {
+			p = append(p, m.apply(vector2.New(values[i], values[i+1])))
+		}
+		if len(p) < 2 {
+			return nil, nil
+		}
+		return []Element{{Shape: p, Open: name == "polyline"}}, nil
+	}
+	return nil, nil
+}
+
+// pathElements converts subpaths into elements. Closed subpaths are
+// flattened; a single one becomes a Polygon and several become one Region
+// filled with rule. Open subpaths keep their circular arcs as Arc
+// values; the straight and curved runs in between become open polylines, or
+// a Line when the run is a single straight segment.
+func pathElements(subpaths []subpath, m matrix, tolerance float64, rule primitive.FillRule) []Element {
+	var elements []Element
+	var rings []primitive.Polygon
+	for _, sp := range subpaths {
+		if len(sp.segments) == 0 {
+			continue
+		}
+		if sp.closed {
+			points := []vector2.Vector2{sp.segments[0].from}
+			for _, s := range sp.segments {
+				points = s.flatten(points, tolerance)
+			}
+			if len(points) > 1 && points[0].IsEqualApprox(points[len(points)-1]) {
+				points = points[:len(points)-1]
+			}
+			rings = append(rings, transformPoints(points, m))
+			continue
+		}
+
+		var run []vector2.Vector2
+		flush := func() {
+			if len(run) == 2 {
+				elements = append(elements, Element{Shape: primitive.Line{Start: m.apply(run[0]), End: m.apply(run[1])}})
+			} else if len(run) > 2 {
+				elements = append(elements, Element{Shape: transformPoints(run, m), Open: true})
+			}
+			run = nil
+		}
+		for _, s := range sp.segments {
+			if arc, ok := s.circularArc(m); ok {
+				flush()
+				elements = append(elements, Element{Shape: arc})
+				continue
+			}
+			if len(run) == 0 {
+				run = append(run, s.from)
+			}
+			run = s.flatten(run, tolerance)
+		}
+		flush()
+	}
+	if len(rings) == 1 {
+		elements = append(elements, Element{Shape: rings[0]})
+	} else if len(rings) > 1 {
+		elements = append(elements, Element{Shape: primitive.NewRegion(rule, rings...)})
+	}
+	return elements
+}
+
+func transformPoints(points []vector2.Vector2, m matrix) primitive.Polygon {
+	p := make(primitive.Polygon, len(points))
+	for i, v := range points {
+		p[i] = m.apply(v)
+	}
+	return p
+}
+
+// Meshes converts the closed elements of the group, but not of its nested
+// groups, into meshes that carry the element styling.
+func (g *Group) Meshes(tolerance float64) []*primitive.Mesh {
+	var meshes []*primitive.Mesh
+	for _, e := range g.Elements {
+		if e.Open {
+			continue
+		}
+		switch e.Shape.(type) {
+		case primitive.Polygon, primitive.Circle, primitive.Ellipse, primitive.Rectangle, primitive.Region:
+		default:
+			continue
+		}
+		m := primitive.NewMesh()
+		m.Region = primitive.RegionFromShape(e.Shape, tolerance)
+		m.Filled = e.Style.Filled
+		m.Color = e.Style.Fill
+		m.OutlineColor = e.Style.Stroke
+		m.OutlineWidth = e.Style.StrokeWidth
+		meshes = append(meshes, m)
+	}
+	return meshes
+}
+
+func attributeMap(attrs []xml.Attr) map[string]string {
+	m := map[string]string{}
+	for _, a := range attrs {
+		m[a.Name.Local] = a.Value
+	}
+	// Inline style declarations take precedence over attributes.
+	for _, decl := range strings.Split(m["style"], ";") {
+		if k, v, ok := strings.Cut(decl, ":"); ok {
+			m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return m
+}
+
+func (p presentation) inherit(attrs map[string]string) presentation {
+	if v, ok := attrs["fill"]; ok {
+		p.fill = v
+	}
+	if v, ok := attrs["stroke"]; ok {
+		p.stroke = v
+	}
+	if v, ok := attrs["fill-rule"]; ok {
+		p.fillRule = v
+	}
+	if v, ok := attrs["stroke-width"]; ok {
+		p.strokeWidth = length(v)
+	}
+	if v, ok := attrs["stroke-dasharray"]; ok {
+		p.dash, _ = parseNumbers(v)
+	}
+	if v, ok := attrs["fill-opacity"]; ok {
+		p.fillOpacity = length(v)
+	}
+	if v, ok := attrs["stroke-opacity"]; ok {
+		p.strokeOpacity = length(v)
+	}
+	if v, ok := attrs["opacity"]; ok {
+		p.opacity *= length(v)
+	}
+	return p
+}
+
+func (p presentation) rule() primitive.FillRule {
+	if p.fillRule == "evenodd" {
+		return primitive.EvenOdd
+	}
+	return primitive.NonZero
+}
+
+func (p presentation) resolve(m matrix) Style {
+	s := Style{}
+	if c, ok := parseColor(p.fill); ok {
+		s.Filled = true
+		s.Fill = c
+		s.Fill[3] = p.fillOpacity * p.opacity
+	}
+	if c, ok := parseColor(p.stroke); ok && p.strokeWidth > 0 {
+		s.Stroke = c
+		s.Stroke[3] = p.strokeOpacity * p.opacity
+		s.StrokeWidth = p.strokeWidth * m.scale()
+		if len(p.dash) > 0 {
+			s.DashLength = p.dash[0] * m.scale()
+			s.DashInterval = s.DashLength
+			if len(p.dash) > 1 {
+				s.DashInterval = p.dash[1] * m.scale()
+			}
+		}
+	}
+	return s
+}
+
+var namedColors = map[string][3]float64{
+	"black": {0, 0, 0}, "white": {1, 1, 1}, "red": {1, 0, 0}, "lime": {0, 1, 0},
+	"green": {0, 128.0 / 255, 0}, "blue": {0, 0, 1}, "yellow": {1, 1, 0},
+	"cyan": {0, 1, 1}, "magenta": {1, 0, 1}, "gray": {128.0 / 255, 128.0 / 255, 128.0 / 255},
+	"grey": {128.0 / 255, 128.0 / 255, 128.0 / 255}, "orange": {1, 165.0 / 255, 0},
+}
+
+// parseColor reads #rgb, #rrggbb, rgb() and the basic named colors. It
+// reports false for "none" and for values it does not understand.
+func parseColor(s string) ([4]float64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if c, ok := namedColors[s]; ok {
+		return [4]float64{c[0], c[1], c[2], 1}, true
+	}
+	if strings.HasPrefix(s, "#") {
+		hex := s[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) != 6 {
+			return [4]float64{}, false
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return [4]float64{}, false
+		}
+		return [4]float64{float64(v>>16&0xff) / 255, float64(v>>8&0xff) / 255, float64(v&0xff) / 255, 1}, true
+	}
+	if strings.HasPrefix(s, "rgb(") && strings.HasSuffix(s, ")") {
+		parts := strings.Split(s[4:len(s)-1], ",")
+		if len(parts) != 3 {
+			return [4]float64{}, false
+		}
+		var c [4]float64
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if strings.HasSuffix(part, "%") {
+				c[i] = length(strings.TrimSuffix(part, "%")) / 100
+			} else {
+				c[i] = length(part) / 255
+			}
+		}
+		c[3] = 1
+		return c, true
+	}
+	return [4]float64{}, false
+}
+
+// length parses a number, ignoring any trailing unit.
+func length(s string) float64 {
+	s = strings.TrimSpace(s)
+	end := len(s)
+	for end > 0 && (s[end-1] < '0' || s[end-1] > '9') && s[end-1] != '.' {
+		end--
+	}
+	v, _ := strconv.ParseFloat(s[:end], 64)
+	return v
+}
@@ -0,0 +1,99 @@
+package svg
+
+import (
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+// Style carries the colors and stroke settings of an element, using the same
+// [4]float64 RGBA colors as the gg drawing routines.
+type Style struct {
+	Filled      bool
+	Fill        [4]float64
+	Stroke      [4]float64
+	StrokeWidth float64
+	// DashLength and DashInterval match the arguments of Shape.DrawDashed.
+	// A zero DashLength draws a solid stroke.
+	DashLength, DashInterval float64
+}
+
+// MeshStyle returns the style a Mesh is drawn with.
+func MeshStyle(m *primitive.Mesh) Style {
+	return Style{
+		Filled:      m.Filled,
+		Fill:        m.Color,
+		Stroke:      m.OutlineColor,
+		StrokeWidth: m.OutlineWidth,
+	}
+}
+
+// Element is a shape with its style.
+type Element struct {
+	Shape primitive.Shape
+	Style Style
+	// Open marks a Polygon that is an open polyline rather than a closed
+	// outline.
+	Open bool
+}
+
+// Group is a named collection of elements and nested groups.
+type Group struct {
+	ID       string
+	Elements []Element
+	Groups   []*Group
+}
+
+// Document is a scene of grouped, styled shapes.
+type Document struct {
+	// Width and Height of the canvas. When zero, the bounds of the content
+	// are used.
+	Width, Height float64
+	Group
+}
+
+func NewDocument(width, height float64) *Document {
+	return &Document{Width: width, Height: height}
+}
+
+func (g *Group) Add(s primitive.Shape, style Style) {
+	g.Elements = append(g.Elements, Element{Shape: s, Style: style})
+}
+
+// AddMesh adds the mesh region, placed at the mesh's Position, with the
+// mesh's fill and outline settings.
+func (g *Group) AddMesh(m *primitive.Mesh) {
+	g.Add(m.Placed(), MeshStyle(m))
+}
+
+// NewGroup adds an empty nested group and returns it.
+func (g *Group) NewGroup(id string) *Group {
+	child := &Group{ID: id}
+	g.Groups = append(g.Groups, child)
+	return child
+}
+
+// GetBoundingBox returns the bounds of every element in the group.
+func (g *Group) GetBoundingBox() (rect2.Rect2, bool) {
+	var bounds rect2.Rect2
+	found := false
+	for _, e := range g.Elements {
+		bb := e.Shape.GetBoundingBox()
+		if !found {
+			bounds = bb
+		} else {
+			bounds = bounds.Merge(bb)
+		}
+		found = true
+	}
+	for _, child := range g.Groups {
+		if bb, ok := child.GetBoundingBox(); ok {
+			if !found {
+				bounds = bb
+			} else {
+				bounds = bounds.Merge(bb)
+			}
+			found = true
+		}
+	}
+	return bounds, found
+}
@@ -0,0 +1,193 @@
+package svg
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+func sameArc(a, b primitive.Arc) bool {
+	return a.Circle.Center.DistanceTo(b.Circle.Center) < 1e-9 &&
+		math.Abs(a.Circle.Radius-b.Circle.Radius) < 1e-9 &&
+		math.Abs(a.Sweep()-b.Sweep()) < 1e-9 &&
+		a.StartPoint().DistanceTo(b.StartPoint()) < 1e-9
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	square := primitive.Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	hole := primitive.Polygon{{X: 2, Y: 2}, {X: 2, Y: 8}, {X: 8, Y: 8}, {X: 8, Y: 2}}
+	shapes := []Element{
+		{Shape: primitive.NewCircle(1.5, -2.25, 1.0/3)},
+		{Shape: primitive.NewRectangle(0.1, 0.2, 3, 4)},
+		{Shape: primitive.Line{Start: vector2.Vector2{X: 1, Y: 2}, End: vector2.Vector2{X: 3, Y: 4.5}}},
+		{Shape: square},
+		{Shape: primitive.Polygon{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}, Open: true},
+		{Shape: primitive.NewArc(0, 0, 10, 0, math.Pi/2)},
+		{Shape: primitive.NewArc(0, 0, 10, 0, -math.Pi/2)},
+		{Shape: primitive.NewArc(5, 5, 2, 1, 1-3*math.Pi/2)},
+		{Shape: primitive.NewRegion(primitive.NonZero, square, hole)},
+		{Shape: primitive.NewEllipse(3, 4, 5, 2, math.Pi/6)},
+	}
+	d := NewDocument(100, 100)
+	d.Elements = shapes
+
+	var b bytes.Buffer
+	if err := d.Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decode(&b, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Elements) != len(shapes) {
+		t.Fatalf("got %d elements, want %d", len(got.Elements), len(shapes))
+	}
+	for i, want := range shapes {
+		e := got.Elements[i]
+		if e.Open != want.Open {
+			t.Errorf("element %d: open = %v, want %v", i, e.Open, want.Open)
+		}
+		switch w := want.Shape.(type) {
+		case primitive.Arc:
+			a, ok := e.Shape.(primitive.Arc)
+			if !ok || !sameArc(a, w) {
+				t.Errorf("element %d: got %+v, want %+v", i, e.Shape, w)
+			}
+		case primitive.Ellipse:
+			el, ok := e.Shape.(primitive.Ellipse)
+			if !ok {
+				t.Fatalf("element %d: got %T, want primitive.Ellipse", i, e.Shape)
+			}
+			for _, angle := range []float64{0, 1, 2, 3, 4, 5} {
+				p := w.PointAt(angle)
+				if d := math.Abs(el.SignedDistance(p)); d > 1e-9 {
+					t.Errorf("element %d: point %v is %g off the outline", i, p, d)
+				}
+			}
+		case primitive.Region:
+			r, ok := e.Shape.(primitive.Region)
+			if !ok || len(r.Rings) != len(w.Rings) {
+				t.Fatalf("element %d: got %+v, want %+v", i, e.Shape, w)
+			}
+			for j := range w.Rings {
+				if !samePolygon(r.Rings[j], w.Rings[j]) {
+					t.Errorf("element %d: ring %d = %v, want %v", i, j, r.Rings[j], w.Rings[j])
+				}
+			}
+		case primitive.Polygon:
+			if p, ok := e.Shape.(primitive.Polygon); !ok || !samePolygon(p, w) {
+				t.Errorf("element %d: got %v, want %v", i, e.Shape, w)
+			}
+		default:
+			if e.Shape != want.Shape {
+				t.Errorf("element %d: got %v, want %v", i, e.Shape, want.Shape)
+			}
+		}
+	}
+}
+
+func samePolygon(a, b primitive.Polygon) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEncodeEllipticalArc(t *testing.T) {
+	for _, sweep := range []float64{2, -2, 5, -5} {
+		arc := primitive.NewEllipticalArc(3, 4, 5, 2, math.Pi/6, 0.5, 0.5+sweep)
+		d := NewDocument(100, 100)
+		d.Add(arc, Style{})
+
+		var b bytes.Buffer
+		if err := d.Encode(&b); err != nil {
+			t.Fatal(err)
+		}
+		got, err := Decode(&b, 0.01)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Elements) != 1 {
+			t.Fatalf("sweep %g: got %d elements, want 1", sweep, len(got.Elements))
+		}
+		points, ok := got.Elements[0].Shape.(primitive.Polygon)
+		if !ok || !got.Elements[0].Open {
+			t.Fatalf("sweep %g: got %T, want an open polyline", sweep, got.Elements[0].Shape)
+		}
+		if points[0].DistanceTo(arc.StartPoint()) > 1e-9 || points[len(points)-1].DistanceTo(arc.EndPoint()) > 1e-9 {
+			t.Errorf("sweep %g: ends %v, %v, want %v, %v", sweep, points[0], points[len(points)-1], arc.StartPoint(), arc.EndPoint())
+		}
+		for _, p := range points {
+			if d := arc.SignedDistance(p); d > 0.01 {
+				t.Fatalf("sweep %g: point %v is %g off the arc", sweep, p, d)
+			}
+		}
+	}
+}
+
+func TestAddMeshExportsAtPosition(t *testing.T) {
+	m := primitive.NewMesh()
+	m.Region = primitive.NewRegion(primitive.NonZero, primitive.Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}})
+	m.Position = vector2.Vector2{X: 20, Y: 5}
+
+	d := NewDocument(100, 100)
+	d.AddMesh(m)
+	var b bytes.Buffer
+	if err := d.Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decode(&b, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Elements) != 1 {
+		t.Fatalf("got %d elements, want 1", len(got.Elements))
+	}
+	if bounds := got.Elements[0].Shape.GetBoundingBox(); bounds.Position != m.Position {
+		t.Errorf("exported mesh starts at %v, want %v", bounds.Position, m.Position)
+	}
+	for _, p := range []vector2.Vector2{{X: 25, Y: 10}, {X: 5, Y: 5}} {
+		if inside, want := got.Elements[0].Shape.SignedDistance(p) < 0, m.SignedDistance(p) < 0; inside != want {
+			t.Errorf("point %v inside exported mesh = %v, want %v", p, inside, want)
+		}
+	}
+}
+
+func TestDecodeDegenerateArcs(t *testing.T) {
+	src := `<svg xmlns="http://www.w3.org/2000/svg">
+<path d="M0 0 L10 0 A5 5 0 0 1 10 0 L10 10"/>
+<path d="M0 0 A0 5 0 0 1 10 0"/>
+</svg>`
+	got, err := Decode(strings.NewReader(src), 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Elements) != 2 {
+		t.Fatalf("got %d elements, want 2", len(got.Elements))
+	}
+	want := []primitive.Shape{
+		primitive.Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}},
+		primitive.Line{Start: vector2.Vector2{X: 0, Y: 0}, End: vector2.Vector2{X: 10, Y: 0}},
+	}
+	for i, e := range got.Elements {
+		switch w := want[i].(type) {
+		case primitive.Polygon:
+			if p, ok := e.Shape.(primitive.Polygon); !ok || !samePolygon(p, w) {
+				t.Errorf("element %d: got %v, want %v", i, e.Shape, w)
+			}
+		default:
+			if e.Shape != w {
+				t.Errorf("element %d: got %v, want %v", i, e.Shape, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// matrix is an SVG transform [a c e; b d f; 0 0 1].
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul returns m applied after n.
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m matrix) apply(v vector2.Vector2) vector2.Vector2 {
+	return vector2.Vector2{
+		X: m[0]*v.X + m[2]*v.Y + m[4],
+		Y: m[1]*v.X + m[3]*v.Y + m[5],
+	}
+}
+
+func (m matrix) det() float64 {
+	return m[0]*m[3] - m[1]*m[2]
+}
+
+// scale returns the uniform scale of a similarity transform.
+func (m matrix) scale() float64 {
+	return math.Sqrt(math.Abs(m.det()))
+}
+
+// isSimilarity reports whether the transform preserves circles.
+func (m matrix) isSimilarity() bool {
+	const eps = 1e-9
+	col1 := math.Hypot(m[0], m[1])
+	col2 := math.Hypot(m[2], m[3])
+	return math.Abs(col1-col2) <= eps*math.Max(col1, 1) && math.Abs(m[0]*m[2]+m[1]*m[3]) <= eps*math.Max(col1*col2, 1)
+}
+
+// isAxisAligned reports whether the transform maps axis-aligned rectangles to
+// axis-aligned rectangles.
+func (m matrix) isAxisAligned() bool {
+	return m[1] == 0 && m[2] == 0
+}
+
+// parseTransform parses an SVG transform attribute.
+func parseTransform(s string) (matrix, error) {
+	result := identity
+	s = strings.TrimSpace(s)
+	for s != "" {
+		open := strings.IndexByte(s, '(')
+		close := strings.IndexByte(s, ')')
+		if open < 0 || close < open {
+			return identity, fmt.Errorf("svg: invalid transform %q", s)
+		}
+		name := strings.TrimSpace(strings.Trim(s[:open], " ,"))
+		args, err := parseNumbers(s[open+1 : close])
+		if err != nil {
+			return identity, err
+		}
+		var m matrix
+		switch {
+		case name == "matrix" && len(args) == 6:
+			m = matrix{args[0], args[1], args[2], args[3], args[4], args[5]}
+		case name == "translate" && len(args) >= 1:
+			ty := 0.0
+			if len(args) > 1 {
+				ty = args[1]
+			}
+			m = matrix{1, 0, 0, 1, args[0], ty}
+		case name == "scale" && len(args) >= 1:
+			sy := args[0]
+			if len(args) > 1 {
+				sy = args[1]
+			}
+			m = matrix{args[0], 0, 0, sy, 0, 0}
+		case name == "rotate" && len(args) >= 1:
+			a := args[0] * math.Pi / 180
+			m = matrix{math.Cos(a), math.Sin(a), -math.Sin(a), math.Cos(a), 0, 0}
+			if len(args) == 3 {
+				m = matrix{1, 0, 0, 1, args[1], args[2]}.mul(m).mul(matrix{1, 0, 0, 1, -args[1], -args[2]})
+			}
+		case name == "skewX" && len(args) == 1:
+			m = matrix{1, 0, math.Tan(args[0] * math.Pi / 180), 1, 0, 0}
+		case name == "skewY" && len(args) == 1:
+			m = matrix{1, math.Tan(args[0] * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return identity, fmt.Errorf("svg: invalid transform %q", s[:close+1])
+		}
+		result = result.mul(m)
+		s = strings.TrimSpace(strings.TrimLeft(s[close+1:], " ,"))
+	}
+	return result, nil
+}
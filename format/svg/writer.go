@@ -0,0 +1,188 @@
+package svg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/primitive"
+)
+
+// Encode writes the document as an SVG file. Every primitive is written as a
+// native element with full float precision: circles as <circle>, ellipses as
+// <ellipse>, rectangles as <rect>, lines as <line>, and polygons, regions,
+// arcs and elliptical arcs as <path>.
+func (d *Document) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	bounds, ok := d.GetBoundingBox()
+	if !ok {
+		bounds = rect2.Rect2{}
+	}
+	if d.Width > 0 && d.Height > 0 {
+		bounds = rect2.Rect2{Size: vector2.New(d.Width, d.Height)}
+	}
+
+	fmt.Fprintf(bw, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="%s %s %s %s">`+"\n",
+		num(bounds.Size.X), num(bounds.Size.Y),
+		num(bounds.Position.X), num(bounds.Position.Y), num(bounds.Size.X), num(bounds.Size.Y))
+	if err := writeGroup(bw, &d.Group, 1); err != nil {
+		return err
+	}
+	fmt.Fprintf(bw, "</svg>\n")
+	return bw.Flush()
+}
+
+func writeGroup(w io.Writer, g *Group, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	for _, e := range g.Elements {
+		element, err := shapeElement(e.Shape, e.Open)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s<%s %s/>\n", indent, element, styleAttributes(e.Style))
+	}
+	for _, child := range g.Groups {
+		if child.ID != "" {
+			fmt.Fprintf(w, "%s<g id=\"%s\">\n", indent, escape(child.ID))
+		} else {
+			fmt.Fprintf(w, "%s<g>\n", indent)
+		}
+		if err := writeGroup(w, child, depth+1); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s</g>\n", indent)
+	}
+	return nil
+}
+
+func shapeElement(s primitive.Shape, open bool) (string, error) {
+	switch s := s.(type) {
+	case primitive.Circle:
+		return fmt.Sprintf(`circle cx="%s" cy="%s" r="%s"`, num(s.Center.X), num(s.Center.Y), num(s.Radius)), nil
+	case primitive.Ellipse:
+		element := fmt.Sprintf(`ellipse cx="%s" cy="%s" rx="%s" ry="%s"`,
+			num(s.Center.X), num(s.Center.Y), num(s.Radii.X), num(s.Radii.Y))
+		if s.Rotation != 0 {
+			element += fmt.Sprintf(` transform="rotate(%s %s %s)"`, num(s.Rotation*180/math.Pi), num(s.Center.X), num(s.Center.Y))
+		}
+		return element, nil
+	case primitive.Rectangle:
+		r := s.GetBoundingBox()
+		return fmt.Sprintf(`rect x="%s" y="%s" width="%s" height="%s"`,
+			num(r.Position.X), num(r.Position.Y), num(r.Size.X), num(r.Size.Y)), nil
+	case primitive.Line:
+		return fmt.Sprintf(`line x1="%s" y1="%s" x2="%s" y2="%s"`,
+			num(s.Start.X), num(s.Start.Y), num(s.End.X), num(s.End.Y)), nil
+	case primitive.Polygon:
+		return fmt.Sprintf(`path d="%s"`, polygonPath(s, !open)), nil
+	case primitive.Arc:
+		return fmt.Sprintf(`path d="%s"`, arcPath(s.Circle.Radius, s.Circle.Radius, 0, s.AngleStart, s.Sweep(), s.PointAt)), nil
+	case primitive.EllipticalArc:
+		e := s.Ellipse
+		return fmt.Sprintf(`path d="%s"`, arcPath(e.Radii.X, e.Radii.Y, e.Rotation, s.AngleStart, s.Sweep(), s.PointAt)), nil
+	case primitive.Region:
+		paths := make([]string, 0, len(s.Rings))
+		for _, ring := range s.Rings {
+			if len(ring) > 0 {
+				paths = append(paths, polygonPath(ring, true))
+			}
+		}
+		rule := "nonzero"
+		if s.Rule == primitive.EvenOdd {
+			rule = "evenodd"
+		}
+		return fmt.Sprintf(`path d="%s" fill-rule="%s"`, strings.Join(paths, " "), rule), nil
+	}
+	return "", fmt.Errorf("svg: unsupported shape %T", s)
+}
+
+func polygonPath(p primitive.Polygon, closed bool) string {
+	var b strings.Builder
+	for i, v := range p {
+		if i == 0 {
+			b.WriteString("M")
+		} else {
+			b.WriteString(" L")
+		}
+		b.WriteString(num(v.X) + " " + num(v.Y))
+	}
+	if closed && len(p) > 0 {
+		b.WriteString(" Z")
+	}
+	return b.String()
+}
+
+// arcPath writes an elliptical arc command for the arc of an ellipse with
+// the given radii and rotation, running sweep radians of its parameter from
+// start. Increasing parameters run with the SVG sweep flag set. Sweeps of a
+// full turn or more are split in two, since a single arc command cannot end
+// where it starts.
+func arcPath(rx, ry, rotation, start, sweep float64, pointAt func(float64) vector2.Vector2) string {
+	steps := 1
+	if math.Abs(sweep) >= math.Pi*2-1e-9 {
+		steps = 2
+	}
+	step := sweep / float64(steps)
+	sweepFlag := "0"
+	// A negative radius mirrors the ellipse and reverses its direction.
+	if (step > 0) != (rx*ry < 0) {
+		sweepFlag = "1"
+	}
+	largeFlag := "0"
+	if math.Abs(step) > math.Pi {
+		largeFlag = "1"
+	}
+
+	var b strings.Builder
+	first := pointAt(start)
+	b.WriteString("M" + num(first.X) + " " + num(first.Y))
+	for i := 1; i <= steps; i++ {
+		end := pointAt(start + float64(i)*step)
+		fmt.Fprintf(&b, " A%s %s %s %s %s %s %s", num(math.Abs(rx)), num(math.Abs(ry)), num(rotation*180/math.Pi), largeFlag, sweepFlag, num(end.X), num(end.Y))
+	}
+	return b.String()
+}
+
+func styleAttributes(s Style) string {
+	attrs := []string{}
+	if s.Filled {
+		attrs = append(attrs, `fill="`+color(s.Fill)+`"`)
+		if s.Fill[3] < 1 {
+			attrs = append(attrs, `fill-opacity="`+num(s.Fill[3])+`"`)
+		}
+	} else {
+		attrs = append(attrs, `fill="none"`)
+	}
+	if s.StrokeWidth > 0 {
+		attrs = append(attrs, `stroke="`+color(s.Stroke)+`"`, `stroke-width="`+num(s.StrokeWidth)+`"`)
+		if s.Stroke[3] < 1 {
+			attrs = append(attrs, `stroke-opacity="`+num(s.Stroke[3])+`"`)
+		}
+		if s.DashLength > 0 {
+			attrs = append(attrs, `stroke-dasharray="`+num(s.DashLength)+" "+num(s.DashInterval)+`"`)
+		}
+	}
+	return strings.Join(attrs, " ")
+}
+
+func color(c [4]float64) string {
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", channel(c[0]), channel(c[1]), channel(c[2]))
+}
+
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
@@ -0,0 +1,58 @@
+package hull
+
+import (
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// MonotoneChain returns the convex hull of a set of points using Andrew's
+// monotone chain algorithm in O(n log n). The hull is counter-clockwise in a
+// y-up frame (positive signed area), starts at the lowest-leftmost point and
+// contains no duplicate or collinear vertices. Fewer than three distinct
+// points are returned as they are, without duplicates.
+func MonotoneChain(points []vector2.Vector2) []vector2.Vector2 {
+	sorted := make([]vector2.Vector2, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	unique := sorted[:0]
+	for i, p := range sorted {
+		if i == 0 || p != sorted[i-1] {
+			unique = append(unique, p)
+		}
+	}
+	if len(unique) < 3 {
+		return unique
+	}
+
+	hull := make([]vector2.Vector2, 0, 2*len(unique))
+	// Lower hull, left to right.
+	for _, p := range unique {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// Upper hull, right to left.
+	lower := len(hull) + 1
+	for i := len(unique) - 2; i >= 0; i-- {
+		p := unique[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// The last point repeats the first.
+	return hull[:len(hull)-1]
+}
+
+// cross returns the z component of (b - a) x (c - a), positive when a, b, c
+// turn counter-clockwise.
+func cross(a, b, c vector2.Vector2) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
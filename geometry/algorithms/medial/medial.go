@@ -0,0 +1,333 @@
+package medial
+
+import (
+	"errors"
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/triangulate"
+)
+
+// ErrTolerance is returned when the tolerance is not positive.
+var ErrTolerance = errors.New("medial: tolerance must be positive")
+
+// Node is a point of the medial axis with the radius of the largest disc
+// centred on it that fits inside the shape, which is also its distance to
+// the boundary.
+type Node struct {
+	Position vector2.Vector2
+	Radius   float64
+}
+
+// Graph is a medial axis: nodes joined by straight edges. Parabolic parts
+// of the axis are split into several edges, and along every edge the radius
+// varies linearly between its nodes.
+type Graph struct {
+	Nodes []Node
+	Edges [][2]int
+}
+
+// Options configures Axis.
+type Options struct {
+	// Tolerance bounds how far the edges may stray from the exact axis, in
+	// both position and radius, wherever the radius is at least Tolerance.
+	// The boundary is sampled every 2*Tolerance, so the cost grows with
+	// perimeter / Tolerance.
+	Tolerance float64
+	// MinAngle prunes edges whose two nearest boundary points, seen from the
+	// edge, are less than MinAngle radians apart. Zero keeps the complete
+	// axis, with a branch into every convex corner; a small angle removes the
+	// branches into the nearly flat corners of sampled curves.
+	MinAngle float64
+}
+
+// feature identifies the boundary element a sample was taken from: vertex
+// index of a ring, or the edge that starts at that vertex.
+type feature struct {
+	ring, index int
+	vertex      bool
+}
+
+// Axis returns the medial axis of the area enclosed by the rings, read with
+// the even-odd rule so that nested rings form holes. The axis is the dual of
+// a Delaunay triangulation of boundary samples: every Voronoi edge between
+// samples from different, non-adjacent boundary elements is kept.
+func Axis(rings [][]vector2.Vector2, opts Options) (Graph, error) {
+	if opts.Tolerance <= 0 {
+		return Graph{}, ErrTolerance
+	}
+	spacing := 2 * opts.Tolerance
+
+	features := map[vector2.Vector2]feature{}
+	lengths := make([]int, len(rings))
+	var sampled [][]vector2.Vector2
+	for r, ring := range rings {
+		ring = cleanRing(ring)
+		lengths[r] = len(ring)
+		if len(ring) < 3 {
+			continue
+		}
+		var samples []vector2.Vector2
+		for i, a := range ring {
+			b := ring[(i+1)%len(ring)]
+			if _, ok := features[a]; !ok {
+				features[a] = feature{ring: r, index: i, vertex: true}
+			}
+			samples = append(samples, a)
+			steps := int(math.Ceil(a.DistanceTo(b) / spacing))
+			for k := 1; k < steps; k++ {
+				p := a.Add(b.Sub(a).Mulf(float64(k) / float64(steps)))
+				if _, ok := features[p]; !ok {
+					features[p] = feature{ring: r, index: i}
+				}
+				samples = append(samples, p)
+			}
+		}
+		sampled = append(sampled, samples)
+	}
+
+	tri, err := triangulate.Constrained(sampled, triangulate.Options{})
+	if err != nil {
+		return Graph{}, err
+	}
+
+	g := Graph{Nodes: make([]Node, len(tri.Triangles))}
+	for t, v := range tri.Triangles {
+		a, b, c := tri.Vertices[v[0]], tri.Vertices[v[1]], tri.Vertices[v[2]]
+		center := circumcenter(a, b, c)
+		g.Nodes[t] = Node{Position: center, Radius: center.DistanceTo(a)}
+	}
+	for t, neighbors := range tri.Neighbors() {
+		for i, n := range neighbors {
+			if n < t {
+				continue
+			}
+			p := tri.Vertices[tri.Triangles[t][i]]
+			q := tri.Vertices[tri.Triangles[t][(i+1)%3]]
+			if adjacent(features[p], features[q], lengths) {
+				continue
+			}
+			if opts.MinAngle > 0 && math.Max(spread(g.Nodes[t].Position, p, q), spread(g.Nodes[n].Position, p, q)) < opts.MinAngle {
+				continue
+			}
+			g.Edges = append(g.Edges, [2]int{t, n})
+		}
+	}
+
+	g = g.compact(opts.Tolerance * 1e-6)
+	return g.simplify(opts.Tolerance), nil
+}
+
+// Adjacency returns the sorted neighbours of every node.
+func (g Graph) Adjacency() [][]int {
+	adjacency := make([][]int, len(g.Nodes))
+	for _, e := range g.Edges {
+		adjacency[e[0]] = append(adjacency[e[0]], e[1])
+		adjacency[e[1]] = append(adjacency[e[1]], e[0])
+	}
+	return adjacency
+}
+
+// Chains splits the graph into maximal paths of node indices that only
+// meet at branch points and ends, ready to be followed as toolpaths. A
+// closed loop without branches starts and ends at the same node.
+func (g Graph) Chains() [][]int {
+	adjacency := g.Adjacency()
+	used := make(map[[2]int]bool, len(g.Edges))
+	var chains [][]int
+	follow := func(start, next int) []int {
+		chain := []int{start}
+		prev, cur := start, next
+		used[[2]int{prev, cur}], used[[2]int{cur, prev}] = true, true
+		for {
+			chain = append(chain, cur)
+			if len(adjacency[cur]) != 2 || cur == start {
+				return chain
+			}
+			n := adjacency[cur][0]
+			if n == prev {
+				n = adjacency[cur][1]
+			}
+			if used[[2]int{cur, n}] {
+				return chain
+			}
+			prev, cur = cur, n
+			used[[2]int{prev, cur}], used[[2]int{cur, prev}] = true, true
+		}
+	}
+	for v, neighbors := range adjacency {
+		if len(neighbors) == 2 {
+			continue
+		}
+		for _, n := range neighbors {
+			if !used[[2]int{v, n}] {
+				chains = append(chains, follow(v, n))
+			}
+		}
+	}
+	for v, neighbors := range adjacency {
+		for _, n := range neighbors {
+			if !used[[2]int{v, n}] {
+				chains = append(chains, follow(v, n))
+			}
+		}
+	}
+	return chains
+}
+
+// compact merges nodes closer than eps, which appear where several
+// triangles share a circumcircle, and drops nodes without edges.
+func (g Graph) compact(eps float64) Graph {
+	parent := make([]int, len(g.Nodes))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, e := range g.Edges {
+		if g.Nodes[e[0]].Position.DistanceTo(g.Nodes[e[1]].Position) <= eps {
+			parent[find(e[0])] = find(e[1])
+		}
+	}
+
+	out := Graph{}
+	index := map[int]int{}
+	node := func(i int) int {
+		root := find(i)
+		if j, ok := index[root]; ok {
+			return j
+		}
+		index[root] = len(out.Nodes)
+		out.Nodes = append(out.Nodes, g.Nodes[root])
+		return index[root]
+	}
+	seen := map[[2]int]bool{}
+	for _, e := range g.Edges {
+		a, b := node(e[0]), node(e[1])
+		if a == b {
+			continue
+		}
+		key := [2]int{min(a, b), max(a, b)}
+		if !seen[key] {
+			seen[key] = true
+			out.Edges = append(out.Edges, key)
+		}
+	}
+	return out
+}
+
+// simplify replaces every chain by a subset of its nodes such that no
+// dropped node lies further than tolerance from the edges that replace it,
+// measuring position and radius together.
+func (g Graph) simplify(tolerance float64) Graph {
+	out := Graph{}
+	index := map[int]int{}
+	node := func(i int) int {
+		if j, ok := index[i]; ok {
+			return j
+		}
+		index[i] = len(out.Nodes)
+		out.Nodes = append(out.Nodes, g.Nodes[i])
+		return index[i]
+	}
+	for _, chain := range g.Chains() {
+		kept := g.reduce(chain, tolerance)
+		for k := 1; k < len(kept); k++ {
+			out.Edges = append(out.Edges, [2]int{node(kept[k-1]), node(kept[k])})
+		}
+	}
+	return out
+}
+
+// reduce applies the Douglas-Peucker algorithm to a chain in (x, y, radius)
+// space.
+func (g Graph) reduce(chain []int, tolerance float64) []int {
+	if len(chain) < 3 {
+		return chain
+	}
+	first, last := g.Nodes[chain[0]], g.Nodes[chain[len(chain)-1]]
+	index, worst := -1, 0.0
+	for i := 1; i < len(chain)-1; i++ {
+		if d := deviation(g.Nodes[chain[i]], first, last); d > worst || index < 0 {
+			index, worst = i, d
+		}
+	}
+	// A closed chain always keeps its farthest node so that it stays a loop.
+	if worst <= tolerance && chain[0] != chain[len(chain)-1] {
+		return []int{chain[0], chain[len(chain)-1]}
+	}
+	left := g.reduce(chain[:index+1], tolerance)
+	right := g.reduce(chain[index:], tolerance)
+	return append(left[:len(left)-1], right...)
+}
+
+// deviation returns the distance from n to the segment ab in (x, y, radius)
+// space.
+func deviation(n, a, b Node) float64 {
+	d := [3]float64{b.Position.X - a.Position.X, b.Position.Y - a.Position.Y, b.Radius - a.Radius}
+	p := [3]float64{n.Position.X - a.Position.X, n.Position.Y - a.Position.Y, n.Radius - a.Radius}
+	length := d[0]*d[0] + d[1]*d[1] + d[2]*d[2]
+	t := 0.0
+	if length > 0 {
+		t = math.Max(0, math.Min(1, (p[0]*d[0]+p[1]*d[1]+p[2]*d[2])/length))
+	}
+	sum := 0.0
+	for i := range p {
+		sum += (p[i] - t*d[i]) * (p[i] - t*d[i])
+	}
+	return math.Sqrt(sum)
+}
+
+// adjacent reports whether two samples come from the same or neighbouring
+// boundary elements. Their Voronoi edge then runs perpendicular to the
+// boundary and is not part of the medial axis.
+func adjacent(f, g feature, lengths []int) bool {
+	if f.ring != g.ring {
+		return false
+	}
+	if f == g {
+		return true
+	}
+	n := lengths[f.ring]
+	if f.vertex && g.vertex {
+		return (f.index+1)%n == g.index || (g.index+1)%n == f.index
+	}
+	if g.vertex {
+		f, g = g, f
+	}
+	// f is a vertex, g an edge: the edges that start and end at f touch it.
+	return f.vertex && (g.index == f.index || (g.index+1)%n == f.index)
+}
+
+// spread returns the angle at center between p and q.
+func spread(center, p, q vector2.Vector2) float64 {
+	u, v := p.Sub(center), q.Sub(center)
+	return math.Abs(math.Atan2(u.Cross(v), u.Dot(v)))
+}
+
+// cleanRing drops repeated consecutive vertices and a closing duplicate.
+func cleanRing(ring []vector2.Vector2) []vector2.Vector2 {
+	var out []vector2.Vector2
+	for _, p := range ring {
+		if len(out) == 0 || out[len(out)-1] != p {
+			out = append(out, p)
+		}
+	}
+	for len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+func circumcenter(a, b, c vector2.Vector2) vector2.Vector2 {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	return vector2.Vector2{X: a.X + (cy*b2-by*c2)/d, Y: a.Y + (bx*c2-cx*b2)/d}
+}
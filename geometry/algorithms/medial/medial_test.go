@@ -0,0 +1,78 @@
+package medial
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// rectangleDistance returns the distance from p to the boundary of the
+// rectangle [0,w]×[0,h].
+func rectangleDistance(p vector2.Vector2, w, h float64) float64 {
+	return math.Min(math.Min(p.X, w-p.X), math.Min(p.Y, h-p.Y))
+}
+
+// onRectangleAxis reports whether p lies within tol of the medial axis of
+// the rectangle [0,w]×[0,h] with w >= h: the spine along y = h/2 and the
+// four branches bisecting the corners.
+func onRectangleAxis(p vector2.Vector2, w, h, tol float64) bool {
+	r := h / 2
+	if math.Abs(p.Y-r) <= tol && p.X >= r-tol && p.X <= w-r+tol {
+		return true
+	}
+	for _, c := range []vector2.Vector2{{X: 0, Y: 0}, {X: w, Y: 0}, {X: w, Y: h}, {X: 0, Y: h}} {
+		dx, dy := math.Abs(p.X-c.X), math.Abs(p.Y-c.Y)
+		if math.Abs(dx-dy) <= tol*math.Sqrt2 && dx <= r+tol {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAxisOfRectangle(t *testing.T) {
+	const w, h, tol = 20.0, 10.0, 0.1
+	rect := []vector2.Vector2{{X: 0, Y: 0}, {X: w, Y: 0}, {X: w, Y: h}, {X: 0, Y: h}}
+	g, err := Axis([][]vector2.Vector2{rect}, Options{Tolerance: tol})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Edges) == 0 {
+		t.Fatal("got an empty axis")
+	}
+
+	for i, n := range g.Nodes {
+		if !onRectangleAxis(n.Position, w, h, tol) {
+			t.Errorf("node %d at %v is off the axis", i, n.Position)
+		}
+		if d := rectangleDistance(n.Position, w, h); math.Abs(n.Radius-d) > tol {
+			t.Errorf("node %d at %v has radius %g, want %g", i, n.Position, n.Radius, d)
+		}
+	}
+
+	// The spine runs from (h/2, h/2) to (w-h/2, h/2) where the discs touch
+	// three sides, and a branch reaches into every corner.
+	for _, want := range []vector2.Vector2{{X: h / 2, Y: h / 2}, {X: w - h/2, Y: h / 2}, {X: 0, Y: 0}, {X: w, Y: 0}, {X: w, Y: h}, {X: 0, Y: h}} {
+		found := false
+		for _, n := range g.Nodes {
+			if n.Position.DistanceTo(want) <= 2*tol {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("no node near %v", want)
+		}
+	}
+
+	if chains := g.Chains(); len(chains) != 5 {
+		t.Errorf("got %d chains, want the spine and four branches", len(chains))
+	}
+}
+
+func TestAxisTolerance(t *testing.T) {
+	triangle := []vector2.Vector2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}}
+	if _, err := Axis([][]vector2.Vector2{triangle}, Options{}); err != ErrTolerance {
+		t.Errorf("got %v, want ErrTolerance", err)
+	}
+}
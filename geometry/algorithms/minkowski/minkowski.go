@@ -0,0 +1,147 @@
+package minkowski
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/hull"
+)
+
+// ConvexSum returns the Minkowski sum of two convex polygons by merging
+// their edges in order of direction, in O(n + m). Either orientation is
+// accepted; the sum is counter-clockwise in a y-up frame and has no
+// collinear vertices. Inputs with fewer than three vertices are summed
+// point by point.
+func ConvexSum(a, b []vector2.Vector2) []vector2.Vector2 {
+	if len(a) < 3 || len(b) < 3 {
+		var points []vector2.Vector2
+		for _, p := range a {
+			for _, q := range b {
+				points = append(points, p.Add(q))
+			}
+		}
+		return hull.MonotoneChain(points)
+	}
+	a, b = lowestFirst(a), lowestFirst(b)
+	n, m := len(a), len(b)
+	sum := make([]vector2.Vector2, 0, n+m)
+	i, j := 0, 0
+	for i < n || j < m {
+		sum = append(sum, a[i%n].Add(b[j%m]))
+		c := a[(i+1)%n].Sub(a[i%n]).Cross(b[(j+1)%m].Sub(b[j%m]))
+		stepA := i < n && (c >= 0 || j == m)
+		stepB := j < m && (c <= 0 || i == n)
+		if stepA {
+			i++
+		}
+		if stepB {
+			j++
+		}
+	}
+	return dropCollinear(sum)
+}
+
+// Convolution returns the parallelograms swept by every edge of a moved
+// along every edge of b, which together cover the sum of the two outlines.
+// Rings may be open or closed, in any orientation and with any number of
+// holes; the parallelograms are counter-clockwise and those without area are
+// left out. The Minkowski sum of the filled shapes is the union of the
+// parallelograms with a copy of a translated to a point of b and copies of
+// b translated to a point on each ring of a.
+func Convolution(a, b [][]vector2.Vector2) [][]vector2.Vector2 {
+	var pieces [][]vector2.Vector2
+	for _, ra := range a {
+		for i := range ra {
+			p, q := ra[i], ra[(i+1)%len(ra)]
+			for _, rb := range b {
+				for j := range rb {
+					r, s := rb[j], rb[(j+1)%len(rb)]
+					if q.Sub(p).Cross(s.Sub(r)) == 0 {
+						continue
+					}
+					piece := []vector2.Vector2{p.Add(r), q.Add(r), q.Add(s), p.Add(s)}
+					if q.Sub(p).Cross(s.Sub(r)) < 0 {
+						piece[1], piece[3] = piece[3], piece[1]
+					}
+					pieces = append(pieces, piece)
+				}
+			}
+		}
+	}
+	return pieces
+}
+
+// IsConvex reports whether a ring is a convex polygon of either orientation.
+// Collinear and repeated vertices are allowed; a ring that winds around more
+// than once, such as a pentagram, is not convex.
+func IsConvex(ring []vector2.Vector2) bool {
+	n := len(ring)
+	if n < 3 {
+		return false
+	}
+	sign := 0.0
+	turning := 0.0
+	var prev vector2.Vector2
+	havePrev := false
+	// Running to k = n turns from the last edge onto the first again.
+	for k := 0; k <= n; k++ {
+		edge := ring[(k+1)%n].Sub(ring[k%n])
+		if edge.X == 0 && edge.Y == 0 {
+			continue
+		}
+		if havePrev {
+			c := prev.Cross(edge)
+			if c*sign < 0 {
+				return false
+			}
+			if c != 0 {
+				sign = c
+			}
+			turning += math.Atan2(c, prev.Dot(edge))
+		}
+		prev, havePrev = edge, true
+	}
+	// A convex ring turns through 2π in total, a star through 4π or more.
+	return sign != 0 && math.Abs(turning) < 3*math.Pi
+}
+
+// lowestFirst returns the ring counter-clockwise, starting at its lowest,
+// then leftmost vertex, without repeated vertices.
+func lowestFirst(ring []vector2.Vector2) []vector2.Vector2 {
+	area := 0.0
+	for i := range ring {
+		area += ring[i].Cross(ring[(i+1)%len(ring)])
+	}
+	var out []vector2.Vector2
+	for i := range ring {
+		v := ring[i]
+		if area < 0 {
+			v = ring[len(ring)-1-i]
+		}
+		if len(out) == 0 || out[len(out)-1] != v {
+			out = append(out, v)
+		}
+	}
+	for len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	start := 0
+	for i, v := range out {
+		if v.Y < out[start].Y || (v.Y == out[start].Y && v.X < out[start].X) {
+			start = i
+		}
+	}
+	return append(out[start:], out[:start]...)
+}
+
+func dropCollinear(ring []vector2.Vector2) []vector2.Vector2 {
+	n := len(ring)
+	out := make([]vector2.Vector2, 0, n)
+	for i := range ring {
+		prev, next := ring[(i+n-1)%n], ring[(i+1)%n]
+		if ring[i].Sub(prev).Cross(next.Sub(ring[i])) != 0 {
+			out = append(out, ring[i])
+		}
+	}
+	return out
+}
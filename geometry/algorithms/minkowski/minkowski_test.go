@@ -0,0 +1,105 @@
+package minkowski
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func box(x0, y0, x1, y1 float64) []vector2.Vector2 {
+	return []vector2.Vector2{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}
+}
+
+func area(ring []vector2.Vector2) float64 {
+	a := 0.0
+	for i, p := range ring {
+		a += p.Cross(ring[(i+1)%len(ring)])
+	}
+	return a / 2
+}
+
+func TestConvexSumOfSquares(t *testing.T) {
+	a := box(0, 0, 2, 2)
+	b := box(-1, -1, 1, 1)
+	// Clockwise input with a collinear vertex gives the same sum.
+	b = []vector2.Vector2{b[3], b[2], {X: 1, Y: 0}, b[1], b[0]}
+
+	got := ConvexSum(a, b)
+	want := box(-1, -1, 3, 3)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].IsEqualApprox(want[i]) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestConvexSumOfSquareAndDiamond(t *testing.T) {
+	// The sum is the square grown by one with its corners cut at 45°: an
+	// octagon of area 5² - 4·½.
+	diamond := []vector2.Vector2{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}, {X: 0, Y: -1}}
+	got := ConvexSum(box(0, 0, 3, 3), diamond)
+	if len(got) != 8 {
+		t.Errorf("got %d vertices, want 8", len(got))
+	}
+	if a := area(got); a != 23 {
+		t.Errorf("got area %g, want 23", a)
+	}
+	for _, v := range got {
+		if math.Abs(v.X-1.5)+math.Abs(v.Y-1.5) > 4 || math.Max(math.Abs(v.X-1.5), math.Abs(v.Y-1.5)) != 2.5 {
+			t.Errorf("vertex %v is not a corner of the octagon", v)
+		}
+	}
+
+	// Fewer than three vertices are summed point by point.
+	segment := []vector2.Vector2{{X: 0, Y: 0}, {X: 2, Y: 0}}
+	if a := area(ConvexSum(box(0, 0, 1, 1), segment)); a != 3 {
+		t.Errorf("square swept along a segment has area %g, want 3", a)
+	}
+}
+
+func TestConvolutionPieces(t *testing.T) {
+	a := [][]vector2.Vector2{box(0, 0, 2, 2)}
+	b := [][]vector2.Vector2{box(-1, -1, 1, 1)}
+	pieces := Convolution(a, b)
+	// Every edge of a sweeps along the two edges of b it is not parallel to.
+	if len(pieces) != 8 {
+		t.Fatalf("got %d pieces, want 8", len(pieces))
+	}
+	for _, p := range pieces {
+		if got := area(p); math.Abs(got-4) > 1e-12 {
+			t.Errorf("piece %v has area %g, want a counter-clockwise 2×2 parallelogram", p, got)
+		}
+		for _, v := range p {
+			if v.X < -1 || v.X > 3 || v.Y < -1 || v.Y > 3 {
+				t.Errorf("piece %v leaves the sum", p)
+			}
+		}
+	}
+}
+
+func TestIsConvex(t *testing.T) {
+	var star []vector2.Vector2
+	for i := 0; i < 5; i++ {
+		angle := float64(i) * 4 * math.Pi / 5
+		star = append(star, vector2.Vector2{X: math.Cos(angle), Y: math.Sin(angle)})
+	}
+	for _, tt := range []struct {
+		name string
+		ring []vector2.Vector2
+		want bool
+	}{
+		{"square", box(0, 0, 1, 1), true},
+		{"clockwise", []vector2.Vector2{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}}, true},
+		{"collinear", []vector2.Vector2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}}, true},
+		{"L shape", []vector2.Vector2{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 2}}, false},
+		{"pentagram", star, false},
+	} {
+		if got := IsConvex(tt.ring); got != tt.want {
+			t.Errorf("%s: IsConvex = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package rtree
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+const (
+	maxEntries = 16
+	minEntries = 6
+)
+
+// Tree is an R-tree of integer ids keyed by axis aligned bounds. Bounds may
+// be degenerate, such as the box of a horizontal line or a point.
+type Tree struct {
+	root   *node
+	height int
+	size   int
+}
+
+// Neighbor is a result of a nearest neighbour search.
+type Neighbor struct {
+	ID       int
+	Distance float64
+}
+
+type entry struct {
+	bounds box
+	id     int
+	child  *node
+}
+
+// node holds leaf entries at height 0 and child nodes above.
+type node struct {
+	entries []entry
+}
+
+func New() *Tree {
+	return &Tree{root: &node{}}
+}
+
+// Len returns the number of ids in the tree.
+func (t *Tree) Len() int {
+	return t.size
+}
+
+// Bounds returns the bounds of every entry in the tree.
+func (t *Tree) Bounds() rect2.Rect2 {
+	return t.root.bounds().rect()
+}
+
+// Insert adds id with the given bounds. An id may be inserted more than once
+// with different bounds.
+func (t *Tree) Insert(id int, bounds rect2.Rect2) {
+	t.insert(entry{bounds: newBox(bounds), id: id}, 0)
+	t.size++
+}
+
+// Remove deletes id inserted with exactly these bounds and reports whether
+// it was found.
+func (t *Tree) Remove(id int, bounds rect2.Rect2) bool {
+	var orphans []entry
+	if !t.remove(t.root, t.height, id, newBox(bounds), &orphans) {
+		return false
+	}
+	t.size--
+	for t.height > 0 && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+		t.height--
+	}
+	if len(t.root.entries) == 0 {
+		t.root, t.height = &node{}, 0
+	}
+	for _, e := range orphans {
+		t.insert(e, 0)
+	}
+	return true
+}
+
+// Search returns the ids whose bounds touch area, in no particular order.
+func (t *Tree) Search(area rect2.Rect2) []int {
+	query := newBox(area)
+	var ids []int
+	stack := []*node{t.root}
+	heights := []int{t.height}
+	for len(stack) > 0 {
+		n, h := stack[len(stack)-1], heights[len(heights)-1]
+		stack, heights = stack[:len(stack)-1], heights[:len(heights)-1]
+		for _, e := range n.entries {
+			if !e.bounds.overlaps(query) {
+				continue
+			}
+			if h == 0 {
+				ids = append(ids, e.id)
+			} else {
+				stack = append(stack, e.child)
+				heights = append(heights, h-1)
+			}
+		}
+	}
+	return ids
+}
+
+// Nearest returns up to k ids closest to p, nearest first. Distances are
+// measured by distance, which must never be smaller than the signed distance
+// from p to the id's bounds (negative inside them); the distance to a shape
+// within the bounds, or the signed distance field of one, qualifies. A nil
+// distance measures the signed distance to the bounds themselves.
+func (t *Tree) Nearest(p vector2.Vector2, k int, distance func(id int) float64) []Neighbor {
+	var result []Neighbor
+	if k <= 0 {
+		return result
+	}
+	queue := &searchQueue{{node: t.root, height: t.height, distance: math.Inf(-1)}}
+	for queue.Len() > 0 && len(result) < k {
+		item := heap.Pop(queue).(searchItem)
+		switch {
+		case item.node == nil && item.exact:
+			result = append(result, Neighbor{ID: item.id, Distance: item.distance})
+		case item.node == nil:
+			item.distance = distance(item.id)
+			item.exact = true
+			heap.Push(queue, item)
+		default:
+			for _, e := range item.node.entries {
+				next := searchItem{distance: e.bounds.distance(p)}
+				if item.height > 0 {
+					next.node, next.height = e.child, item.height-1
+				} else {
+					next.id = e.id
+					next.exact = distance == nil
+				}
+				heap.Push(queue, next)
+			}
+		}
+	}
+	return result
+}
+
+// insert adds e to a node at the given height, growing the tree at the
+// root when the split propagates all the way up.
+func (t *Tree) insert(e entry, height int) {
+	split := t.insertInto(t.root, t.height, e, height)
+	if split == nil {
+		return
+	}
+	old := t.root
+	t.root = &node{entries: []entry{
+		{bounds: old.bounds(), child: old},
+		{bounds: split.bounds(), child: split},
+	}}
+	t.height++
+}
+
+// insertInto returns the new sibling of n when n had to be split.
+func (t *Tree) insertInto(n *node, nodeHeight int, e entry, height int) *node {
+	if nodeHeight == height {
+		n.entries = append(n.entries, e)
+	} else {
+		i := chooseSubtree(n, e.bounds)
+		child := n.entries[i].child
+		split := t.insertInto(child, nodeHeight-1, e, height)
+		n.entries[i].bounds = child.bounds()
+		if split != nil {
+			n.entries = append(n.entries, entry{bounds: split.bounds(), child: split})
+		}
+	}
+	if len(n.entries) > maxEntries {
+		return n.split()
+	}
+	return nil
+}
+
+// remove deletes the entry below n. Nodes left with too few entries are
+// detached and their leaf entries collected in orphans for reinsertion.
+func (t *Tree) remove(n *node, nodeHeight, id int, bounds box, orphans *[]entry) bool {
+	for i, e := range n.entries {
+		if !e.bounds.encloses(bounds) {
+			continue
+		}
+		if nodeHeight == 0 {
+			if e.id != id || e.bounds != bounds {
+				continue
+			}
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			return true
+		}
+		if !t.remove(e.child, nodeHeight-1, id, bounds, orphans) {
+			continue
+		}
+		if len(e.child.entries) < minEntries {
+			*orphans = append(*orphans, e.child.leaves(nodeHeight-1)...)
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+		} else {
+			n.entries[i].bounds = e.child.bounds()
+		}
+		return true
+	}
+	return false
+}
+
+// leaves returns the leaf entries below a node of the given height.
+func (n *node) leaves(height int) []entry {
+	if height == 0 {
+		return n.entries
+	}
+	var entries []entry
+	for _, e := range n.entries {
+		entries = append(entries, e.child.leaves(height-1)...)
+	}
+	return entries
+}
+
+func (n *node) bounds() box {
+	if len(n.entries) == 0 {
+		return box{}
+	}
+	b := n.entries[0].bounds
+	for _, e := range n.entries[1:] {
+		b = b.merge(e.bounds)
+	}
+	return b
+}
+
+// chooseSubtree picks the child needing the least area enlargement, then
+// the least perimeter enlargement, then the smallest area.
+func chooseSubtree(n *node, b box) int {
+	best := 0
+	bestArea, bestMargin, bestSize := math.Inf(1), math.Inf(1), math.Inf(1)
+	for i, e := range n.entries {
+		merged := e.bounds.merge(b)
+		grow := merged.area() - e.bounds.area()
+		growMargin := merged.margin() - e.bounds.margin()
+		size := e.bounds.area()
+		if grow < bestArea || (grow == bestArea && (growMargin < bestMargin || (growMargin == bestMargin && size < bestSize))) {
+			best, bestArea, bestMargin, bestSize = i, grow, growMargin, size
+		}
+	}
+	return best
+}
+
+// split divides an overflowing node in the manner of the R*-tree: the axis
+// with the smallest total margin is chosen, then the distribution along it
+// with the least overlap. The receiver keeps the first group and the second
+// is returned.
+func (n *node) split() *node {
+	entries := n.entries
+	byAxis := func(axis int) []entry {
+		sorted := append([]entry{}, entries...)
+		sort.Slice(sorted, func(i, j int) bool {
+			a, b := sorted[i].bounds, sorted[j].bounds
+			if axis == 0 {
+				return a.minX+a.maxX < b.minX+b.maxX
+			}
+			return a.minY+a.maxY < b.minY+b.maxY
+		})
+		return sorted
+	}
+
+	var best []entry
+	bestMargin := math.Inf(1)
+	for axis := 0; axis < 2; axis++ {
+		sorted := byAxis(axis)
+		total := 0.0
+		for k := minEntries; k <= len(sorted)-minEntries; k++ {
+			total += group(sorted[:k]).margin() + group(sorted[k:]).margin()
+		}
+		if total < bestMargin {
+			best, bestMargin = sorted, total
+		}
+	}
+
+	cut := minEntries
+	bestOverlap, bestArea := math.Inf(1), math.Inf(1)
+	for k := minEntries; k <= len(best)-minEntries; k++ {
+		a, b := group(best[:k]), group(best[k:])
+		overlap := a.overlap(b)
+		size := a.area() + b.area()
+		if overlap < bestOverlap || (overlap == bestOverlap && size < bestArea) {
+			cut, bestOverlap, bestArea = k, overlap, size
+		}
+	}
+	n.entries = append([]entry{}, best[:cut]...)
+	return &node{entries: append([]entry{}, best[cut:]...)}
+}
+
+func group(entries []entry) box {
+	return (&node{entries: entries}).bounds()
+}
+
+// box is an axis aligned box stored by its extremes, so that merged bounds
+// enclose their parts exactly.
+type box struct {
+	minX, minY, maxX, maxY float64
+}
+
+func newBox(r rect2.Rect2) box {
+	b := box{r.Position.X, r.Position.Y, r.Position.X + r.Size.X, r.Position.Y + r.Size.Y}
+	if b.maxX < b.minX {
+		b.minX, b.maxX = b.maxX, b.minX
+	}
+	if b.maxY < b.minY {
+		b.minY, b.maxY = b.maxY, b.minY
+	}
+	return b
+}
+
+func (b box) rect() rect2.Rect2 {
+	return rect2.Rect2{
+		Position: vector2.Vector2{X: b.minX, Y: b.minY},
+		Size:     vector2.Vector2{X: b.maxX - b.minX, Y: b.maxY - b.minY},
+	}
+}
+
+func (b box) merge(o box) box {
+	return box{math.Min(b.minX, o.minX), math.Min(b.minY, o.minY), math.Max(b.maxX, o.maxX), math.Max(b.maxY, o.maxY)}
+}
+
+// overlap returns the area shared by two boxes.
+func (b box) overlap(o box) float64 {
+	w := math.Min(b.maxX, o.maxX) - math.Max(b.minX, o.minX)
+	h := math.Min(b.maxY, o.maxY) - math.Max(b.minY, o.minY)
+	if w < 0 || h < 0 {
+		return 0
+	}
+	return w * h
+}
+
+// overlaps reports whether two closed boxes share at least one point.
+func (b box) overlaps(o box) bool {
+	return b.minX <= o.maxX && o.minX <= b.maxX && b.minY <= o.maxY && o.minY <= b.maxY
+}
+
+func (b box) encloses(o box) bool {
+	return b.minX <= o.minX && b.minY <= o.minY && o.maxX <= b.maxX && o.maxY <= b.maxY
+}
+
+func (b box) area() float64 {
+	return (b.maxX - b.minX) * (b.maxY - b.minY)
+}
+
+func (b box) margin() float64 {
+	return (b.maxX - b.minX) + (b.maxY - b.minY)
+}
+
+// distance returns the signed distance from p to the box, negative inside.
+// It never exceeds the signed distance to anything the box encloses, which
+// makes it a lower bound for both the search and signed distance fields.
+func (b box) distance(p vector2.Vector2) float64 {
+	dx := math.Max(b.minX-p.X, p.X-b.maxX)
+	dy := math.Max(b.minY-p.Y, p.Y-b.maxY)
+	if dx <= 0 && dy <= 0 {
+		return math.Max(dx, dy)
+	}
+	return math.Hypot(math.Max(dx, 0), math.Max(dy, 0))
+}
+
+// searchItem is a node or an id waiting in the nearest neighbour queue.
+// Ids enter with the distance to their bounds and are queued again with
+// their exact distance once it is known.
+type searchItem struct {
+	node     *node
+	height   int
+	id       int
+	exact    bool
+	distance float64
+}
+
+type searchQueue []searchItem
+
+func (q searchQueue) Len() int { return len(q) }
+func (q searchQueue) Less(i, j int) bool {
+	if q[i].distance != q[j].distance {
+		return q[i].distance < q[j].distance
+	}
+	// Resolve ties in favour of exact results so that they are returned
+	// before bounds that could only match them.
+	return q[i].exact && !q[j].exact
+}
+func (q searchQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *searchQueue) Push(x any)   { *q = append(*q, x.(searchItem)) }
+func (q *searchQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
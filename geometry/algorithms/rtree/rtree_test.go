@@ -0,0 +1,143 @@
+package rtree
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// randomBounds returns n boxes in [0,100]², one in five of them degenerate.
+func randomBounds(r *rand.Rand, n int) []rect2.Rect2 {
+	bounds := make([]rect2.Rect2, n)
+	for i := range bounds {
+		size := vector2.Vector2{X: r.Float64() * 5, Y: r.Float64() * 5}
+		switch i % 5 {
+		case 0:
+			size.Y = 0
+		case 1:
+			size = vector2.Vector2{}
+		}
+		bounds[i] = rect2.Rect2{Position: vector2.Vector2{X: r.Float64() * 100, Y: r.Float64() * 100}, Size: size}
+	}
+	return bounds
+}
+
+// touches is the brute force test Search has to agree with.
+func touches(a, b rect2.Rect2) bool {
+	return a.Position.X <= b.Position.X+b.Size.X && b.Position.X <= a.Position.X+a.Size.X &&
+		a.Position.Y <= b.Position.Y+b.Size.Y && b.Position.Y <= a.Position.Y+a.Size.Y
+}
+
+// signedDistance is the brute force distance Nearest measures without a
+// distance function.
+func signedDistance(b rect2.Rect2, p vector2.Vector2) float64 {
+	dx := math.Max(b.Position.X-p.X, p.X-b.Position.X-b.Size.X)
+	dy := math.Max(b.Position.Y-p.Y, p.Y-b.Position.Y-b.Size.Y)
+	if dx <= 0 && dy <= 0 {
+		return math.Max(dx, dy)
+	}
+	return math.Hypot(math.Max(dx, 0), math.Max(dy, 0))
+}
+
+func checkSearch(t *testing.T, tree *Tree, bounds []rect2.Rect2, live map[int]bool, r *rand.Rand) {
+	t.Helper()
+	for q := 0; q < 100; q++ {
+		area := randomBounds(r, 1)[0]
+		area.Size = area.Size.Mulf(4)
+		var want []int
+		for id := range live {
+			if touches(bounds[id], area) {
+				want = append(want, id)
+			}
+		}
+		got := tree.Search(area)
+		sort.Ints(got)
+		sort.Ints(want)
+		if len(got) != len(want) {
+			t.Fatalf("Search(%v) found %d ids, want %d", area, len(got), len(want))
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("Search(%v) = %v, want %v", area, got, want)
+			}
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	bounds := randomBounds(r, 1000)
+	tree := New()
+	live := map[int]bool{}
+	for id, b := range bounds {
+		tree.Insert(id, b)
+		live[id] = true
+	}
+	checkSearch(t, tree, bounds, live, r)
+
+	for id := 0; id < len(bounds); id += 2 {
+		if !tree.Remove(id, bounds[id]) {
+			t.Fatalf("Remove(%d) did not find the id", id)
+		}
+		delete(live, id)
+	}
+	if tree.Remove(0, bounds[0]) {
+		t.Error("removed id 0 twice")
+	}
+	if tree.Len() != len(live) {
+		t.Errorf("Len() = %d, want %d", tree.Len(), len(live))
+	}
+	checkSearch(t, tree, bounds, live, r)
+}
+
+func TestNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	bounds := randomBounds(r, 1000)
+	tree := New()
+	for id, b := range bounds {
+		tree.Insert(id, b)
+	}
+
+	const k = 5
+	for q := 0; q < 100; q++ {
+		p := vector2.Vector2{X: r.Float64()*120 - 10, Y: r.Float64()*120 - 10}
+		want := make([]float64, len(bounds))
+		for id, b := range bounds {
+			want[id] = signedDistance(b, p)
+		}
+		sort.Float64s(want)
+
+		got := tree.Nearest(p, k, nil)
+		if len(got) != k {
+			t.Fatalf("Nearest(%v) returned %d ids, want %d", p, len(got), k)
+		}
+		for i, n := range got {
+			if math.Abs(n.Distance-want[i]) > 1e-12 || math.Abs(signedDistance(bounds[n.ID], p)-n.Distance) > 1e-12 {
+				t.Fatalf("Nearest(%v)[%d] = %v, want distance %g", p, i, n, want[i])
+			}
+		}
+	}
+
+	// A distance function ranks by the shapes inside the bounds: here the
+	// centres of the boxes.
+	p := vector2.Vector2{X: 50, Y: 50}
+	centre := func(id int) float64 {
+		return bounds[id].Position.Add(bounds[id].Size.Divf(2)).DistanceTo(p)
+	}
+	best := 0
+	for id := range bounds {
+		if centre(id) < centre(best) {
+			best = id
+		}
+	}
+	if got := tree.Nearest(p, 1, centre); len(got) != 1 || got[0].ID != best {
+		t.Errorf("Nearest by centre = %v, want id %d", got, best)
+	}
+	if got := New().Nearest(p, 1, nil); len(got) != 0 {
+		t.Errorf("Nearest in an empty tree = %v", got)
+	}
+}
@@ -0,0 +1,289 @@
+package sweep
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// Intersection is a point shared by two or more segments, with the sorted
+// indices of every segment that passes through or ends at it.
+type Intersection struct {
+	Point    vector2.Vector2
+	Segments []int
+}
+
+// BentleyOttmann reports every point where two or more segments meet, in
+// O((n + k) log n) expected time for n segments and k intersections. Crossings,
+// touching end points and the ends of collinear overlaps are all reported,
+// so edges that share a vertex meet there too. Points closer than a small
+// tolerance relative to the extent of the input are treated as one.
+func BentleyOttmann(segments [][2]vector2.Vector2) []Intersection {
+	s := &sweeper{
+		segments: make([][2]vector2.Vector2, len(segments)),
+		events:   map[vector2.Vector2]*event{},
+		seed:     0x9E3779B97F4A7C15,
+	}
+	extent := 1.0
+	for i, seg := range segments {
+		a, b := seg[0], seg[1]
+		if before(b, a) {
+			a, b = b, a
+		}
+		s.segments[i] = [2]vector2.Vector2{a, b}
+		s.event(a).starts = append(s.event(a).starts, i)
+		s.event(b)
+		extent = math.Max(extent, math.Max(math.Max(math.Abs(a.X), math.Abs(a.Y)), math.Max(math.Abs(b.X), math.Abs(b.Y))))
+	}
+	s.eps = 1e-9 * extent
+
+	for s.queue.Len() > 0 {
+		e := heap.Pop(&s.queue).(*event)
+		delete(s.events, e.point)
+		// Fold in events that only differ by round-off.
+		for s.queue.Len() > 0 && near(s.queue[0].point, e.point, s.eps) {
+			next := heap.Pop(&s.queue).(*event)
+			delete(s.events, next.point)
+			e.starts = append(e.starts, next.starts...)
+		}
+		s.handle(e)
+	}
+	return s.result
+}
+
+type event struct {
+	point  vector2.Vector2
+	starts []int
+}
+
+// sweeper moves a vertical line from left to right. status holds the
+// non-vertical segments crossing the line ordered by height, in a treap so
+// that each event costs O(log n); vertical segments are only alive at a
+// single x and are kept apart.
+type sweeper struct {
+	segments  [][2]vector2.Vector2
+	eps       float64
+	queue     eventQueue
+	events    map[vector2.Vector2]*event
+	status    *node
+	seed      uint64
+	verticals []int
+	current   vector2.Vector2
+	result    []Intersection
+}
+
+// event returns the queued event at p, adding it if needed.
+func (s *sweeper) event(p vector2.Vector2) *event {
+	if e, ok := s.events[p]; ok {
+		return e
+	}
+	e := &event{point: p}
+	s.events[p] = e
+	heap.Push(&s.queue, e)
+	return e
+}
+
+// node returns a status node for a segment with a pseudo-random priority.
+func (s *sweeper) node(id int) *node {
+	s.seed ^= s.seed << 13
+	s.seed ^= s.seed >> 7
+	s.seed ^= s.seed << 17
+	return &node{id: id, priority: s.seed}
+}
+
+func (s *sweeper) handle(e *event) {
+	p := e.point
+	s.current = p
+
+	below, through, above := s.containing(p)
+	passing := appendIDs(nil, through)
+	involved := map[int]bool{}
+	for _, id := range e.starts {
+		involved[id] = true
+	}
+	for _, id := range passing {
+		involved[id] = true
+	}
+	var verticals []int
+	for _, id := range s.verticals {
+		seg := s.segments[id]
+		if p.Y >= seg[0].Y-s.eps && p.Y <= seg[1].Y+s.eps {
+			involved[id] = true
+		}
+		if !near(seg[1], p, s.eps) {
+			verticals = append(verticals, id)
+		}
+	}
+	s.verticals = verticals
+	if len(involved) > 1 {
+		ids := make([]int, 0, len(involved))
+		for id := range involved {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		s.result = append(s.result, Intersection{Point: p, Segments: ids})
+	}
+
+	// Segments that continue past p are reinserted with those that start
+	// there, ordered by slope, which is their order just right of p.
+	var insert, started []int
+	for _, id := range passing {
+		if !near(s.segments[id][1], p, s.eps) {
+			insert = append(insert, id)
+		}
+	}
+	for _, id := range e.starts {
+		seg := s.segments[id]
+		switch {
+		case near(seg[0], seg[1], s.eps):
+		case seg[1].X-seg[0].X <= s.eps:
+			started = append(started, id)
+		default:
+			insert = append(insert, id)
+		}
+	}
+	sort.SliceStable(insert, func(i, j int) bool {
+		return s.slope(insert[i]) < s.slope(insert[j])
+	})
+	// Find the neighbours before merging, which relinks the nodes.
+	lower, upper := last(below), first(above)
+	var inserted *node
+	for _, id := range insert {
+		inserted = merge(inserted, s.node(id))
+	}
+	s.status = merge(merge(below, inserted), above)
+	for _, id := range started {
+		s.verticals = append(s.verticals, id)
+		s.crossVertical(id)
+	}
+
+	if len(insert) == 0 {
+		if lower != nil && upper != nil {
+			s.check(lower.id, upper.id)
+		}
+		return
+	}
+	if lower != nil {
+		s.check(lower.id, insert[0])
+	}
+	if upper != nil {
+		s.check(insert[len(insert)-1], upper.id)
+	}
+}
+
+// containing splits the status into the segments below p, those that pass
+// within tolerance of it, and those above.
+func (s *sweeper) containing(p vector2.Vector2) (below, through, above *node) {
+	within := func(id int) bool {
+		return math.Abs(s.heightAt(id, p.X)-p.Y) <= s.eps
+	}
+	below, rest := split(s.status, func(id int) bool {
+		return s.heightAt(id, p.X) >= p.Y-s.eps
+	})
+	for n := last(below); n != nil && within(n.id); n = last(below) {
+		below, n = popLast(below)
+		rest = merge(n, rest)
+	}
+	through, above = split(rest, func(id int) bool { return !within(id) })
+	return below, through, above
+}
+
+// crossVertical queues the points where a vertical segment crosses the
+// segments in the status.
+func (s *sweeper) crossVertical(id int) {
+	seg := s.segments[id]
+	x := seg[0].X
+	from := func(other int) bool {
+		return s.heightAt(other, x) > seg[0].Y+s.eps
+	}
+	ascend(s.status, from, func(other int) bool {
+		y := s.heightAt(other, x)
+		if y > seg[1].Y+s.eps {
+			return false
+		}
+		s.event(s.snap(vector2.Vector2{X: x, Y: y}, id, other))
+		return true
+	})
+}
+
+// check queues the intersection of two neighbouring segments when it lies
+// beyond the sweep line.
+func (s *sweeper) check(i, j int) {
+	p, ok := s.intersect(i, j)
+	if !ok || near(p, s.current, s.eps) || !before(s.current, p) {
+		return
+	}
+	s.event(p)
+}
+
+// intersect returns the point where two non-parallel segments meet. Points
+// within tolerance of an end point are snapped to it so that they merge
+// with its event.
+func (s *sweeper) intersect(i, j int) (vector2.Vector2, bool) {
+	a, b := s.segments[i][0], s.segments[i][1]
+	c, d := s.segments[j][0], s.segments[j][1]
+	r, q := b.Sub(a), d.Sub(c)
+	denom := r.Cross(q)
+	if math.Abs(denom) <= s.eps*s.eps {
+		return vector2.Vector2{}, false
+	}
+	t := c.Sub(a).Cross(q) / denom
+	u := c.Sub(a).Cross(r) / denom
+	te, ue := s.eps/r.Length(), s.eps/q.Length()
+	if t < -te || t > 1+te || u < -ue || u > 1+ue {
+		return vector2.Vector2{}, false
+	}
+	return s.snap(a.Add(r.Mulf(t)), i, j), true
+}
+
+func (s *sweeper) snap(p vector2.Vector2, i, j int) vector2.Vector2 {
+	for _, id := range []int{i, j} {
+		for _, end := range s.segments[id] {
+			if near(p, end, s.eps) {
+				return end
+			}
+		}
+	}
+	return p
+}
+
+// heightAt returns the y coordinate of a non-vertical segment at x.
+func (s *sweeper) heightAt(id int, x float64) float64 {
+	a, b := s.segments[id][0], s.segments[id][1]
+	switch x {
+	case a.X:
+		return a.Y
+	case b.X:
+		return b.Y
+	}
+	return a.Y + (x-a.X)*(b.Y-a.Y)/(b.X-a.X)
+}
+
+func (s *sweeper) slope(id int) float64 {
+	a, b := s.segments[id][0], s.segments[id][1]
+	return (b.Y - a.Y) / (b.X - a.X)
+}
+
+// before orders points by x, then by y.
+func before(p, q vector2.Vector2) bool {
+	return p.X < q.X || (p.X == q.X && p.Y < q.Y)
+}
+
+func near(p, q vector2.Vector2, eps float64) bool {
+	return math.Abs(p.X-q.X) <= eps && math.Abs(p.Y-q.Y) <= eps
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int           { return len(q) }
+func (q eventQueue) Less(i, j int) bool { return before(q[i].point, q[j].point) }
+func (q eventQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *eventQueue) Push(x any)        { *q = append(*q, x.(*event)) }
+func (q *eventQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
@@ -0,0 +1,73 @@
+package sweep
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func TestBentleyOttmannGrid(t *testing.T) {
+	var segments [][2]vector2.Vector2
+	for i := 0; i < 5; i++ {
+		y := float64(i)*2 + 1
+		segments = append(segments, [2]vector2.Vector2{{X: 0, Y: y}, {X: 10, Y: y + 0.5}})
+	}
+	for i := 0; i < 5; i++ {
+		x := float64(i)*2 + 1
+		segments = append(segments, [2]vector2.Vector2{{X: x, Y: 0}, {X: x + 0.5, Y: 11}})
+	}
+	got := BentleyOttmann(segments)
+	if len(got) != 25 {
+		t.Fatalf("got %d intersections, want 25", len(got))
+	}
+	for _, in := range got {
+		if len(in.Segments) != 2 || in.Segments[0] >= 5 || in.Segments[1] < 5 {
+			t.Errorf("intersection %v joins %v, want one row and one column", in.Point, in.Segments)
+		}
+	}
+}
+
+func TestBentleyOttmannMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	point := func() vector2.Vector2 { return vector2.Vector2{X: r.Float64() * 100, Y: r.Float64() * 100} }
+	for round := 0; round < 20; round++ {
+		var segments [][2]vector2.Vector2
+		for i := 0; i < 60; i++ {
+			a, b := point(), point()
+			if i%10 == 0 {
+				b.X = a.X
+			}
+			segments = append(segments, [2]vector2.Vector2{a, b})
+		}
+		want := map[[2]int]bool{}
+		for i := range segments {
+			for j := i + 1; j < len(segments); j++ {
+				if cross(segments[i], segments[j]) {
+					want[[2]int{i, j}] = true
+				}
+			}
+		}
+		got := map[[2]int]bool{}
+		for _, in := range BentleyOttmann(segments) {
+			if len(in.Segments) != 2 {
+				t.Fatalf("round %d: %v joins %v, want a pair", round, in.Point, in.Segments)
+			}
+			got[[2]int{in.Segments[0], in.Segments[1]}] = true
+		}
+		if len(got) != len(want) {
+			t.Errorf("round %d: got %d crossings, want %d", round, len(got), len(want))
+		}
+		for pair := range want {
+			if !got[pair] {
+				t.Errorf("round %d: missed the crossing of %v", round, pair)
+			}
+		}
+	}
+}
+
+// cross reports whether two segments in general position cross.
+func cross(s, u [2]vector2.Vector2) bool {
+	side := func(a, b, p vector2.Vector2) float64 { return b.Sub(a).Cross(p.Sub(a)) }
+	return side(s[0], s[1], u[0])*side(s[0], s[1], u[1]) < 0 && side(u[0], u[1], s[0])*side(u[0], u[1], s[1]) < 0
+}
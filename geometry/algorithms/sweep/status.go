@@ -0,0 +1,96 @@
+package sweep
+
+// node is an entry of the sweep status, a treap of segment ids ordered by
+// height along the sweep line. The order is only ever compared at the current
+// sweep position, through the predicates passed to split and ascend, so
+// nodes carry no key of their own.
+type node struct {
+	id          int
+	priority    uint64
+	left, right *node
+}
+
+// merge joins two treaps where every node of a comes before every node of b.
+func merge(a, b *node) *node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = merge(a.right, b)
+		return a
+	}
+	b.left = merge(a, b.left)
+	return b
+}
+
+// split separates t into the nodes before the first one for which atOrAfter
+// holds and the rest. atOrAfter has to be false for a prefix of the nodes and
+// true for the remainder.
+func split(t *node, atOrAfter func(id int) bool) (*node, *node) {
+	if t == nil {
+		return nil, nil
+	}
+	if atOrAfter(t.id) {
+		l, r := split(t.left, atOrAfter)
+		t.left = r
+		return l, t
+	}
+	l, r := split(t.right, atOrAfter)
+	t.right = l
+	return t, r
+}
+
+// popLast removes the last node of t and returns the remaining treap and the
+// node.
+func popLast(t *node) (*node, *node) {
+	if t.right == nil {
+		rest := t.left
+		t.left = nil
+		return rest, t
+	}
+	var n *node
+	t.right, n = popLast(t.right)
+	return t, n
+}
+
+func first(t *node) *node {
+	for t != nil && t.left != nil {
+		t = t.left
+	}
+	return t
+}
+
+func last(t *node) *node {
+	for t != nil && t.right != nil {
+		t = t.right
+	}
+	return t
+}
+
+// appendIDs appends the ids of t in order.
+func appendIDs(ids []int, t *node) []int {
+	if t == nil {
+		return ids
+	}
+	ids = appendIDs(ids, t.left)
+	ids = append(ids, t.id)
+	return appendIDs(ids, t.right)
+}
+
+// ascend calls visit for the nodes of t in order, starting at the first node
+// for which from holds, until visit returns false. It reports whether the
+// walk ran to the end.
+func ascend(t *node, from func(id int) bool, visit func(id int) bool) bool {
+	if t == nil {
+		return true
+	}
+	if from(t.id) {
+		if !ascend(t.left, from, visit) || !visit(t.id) {
+			return false
+		}
+	}
+	return ascend(t.right, from, visit)
+}
@@ -0,0 +1,480 @@
+package triangulate
+
+import (
+	"errors"
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// ErrCrossingEdges is returned when two constraint edges intersect away from
+// their endpoints.
+var ErrCrossingEdges = errors.New("triangulate: constraint edges cross")
+
+// superVertices is the number of vertices of the enclosing super triangle,
+// which occupy the first indices of every triangulation under construction.
+const superVertices = 3
+
+type triangle struct {
+	v      [3]int
+	dead   bool
+	inside bool
+}
+
+// cdt is an incremental constrained Delaunay triangulation. Triangles are
+// linked through a map of directed edges; the triangle on the other side of
+// edge (a, b) is the one that owns (b, a).
+type cdt struct {
+	points      []vector2.Vector2
+	tris        []triangle
+	edges       map[[2]int]int
+	constrained map[[2]int]bool
+	last        int
+	created     []int
+}
+
+// newCDT starts a triangulation with a super triangle that encloses bounds
+// by a wide margin.
+func newCDT(points []vector2.Vector2) *cdt {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+		maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+	}
+	cx, cy := (minX+maxX)/2, (minY+maxY)/2
+	size := math.Max(math.Max(maxX-minX, maxY-minY), 1) * 64
+	c := &cdt{
+		points: []vector2.Vector2{
+			{X: cx - size, Y: cy - size},
+			{X: cx + size, Y: cy - size},
+			{X: cx, Y: cy + size},
+		},
+		edges:       map[[2]int]int{},
+		constrained: map[[2]int]bool{},
+	}
+	c.addTriangle(0, 1, 2, false)
+	return c
+}
+
+func edgeKey(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+func (c *cdt) addTriangle(a, b, d int, inside bool) int {
+	if orient(c.points[a], c.points[b], c.points[d]) < 0 {
+		b, d = d, b
+	}
+	t := len(c.tris)
+	c.tris = append(c.tris, triangle{v: [3]int{a, b, d}, inside: inside})
+	c.edges[[2]int{a, b}] = t
+	c.edges[[2]int{b, d}] = t
+	c.edges[[2]int{d, a}] = t
+	c.last = t
+	c.created = append(c.created, t)
+	return t
+}
+
+func (c *cdt) removeTriangle(t int) {
+	v := c.tris[t].v
+	for i := 0; i < 3; i++ {
+		e := [2]int{v[i], v[(i+1)%3]}
+		if c.edges[e] == t {
+			delete(c.edges, e)
+		}
+	}
+	c.tris[t].dead = true
+}
+
+// neighbor returns the triangle across edge i of t, the edge from v[i] to
+// v[i+1], or -1 on the outer boundary.
+func (c *cdt) neighbor(t, i int) int {
+	v := c.tris[t].v
+	if n, ok := c.edges[[2]int{v[(i+1)%3], v[i]}]; ok {
+		return n
+	}
+	return -1
+}
+
+// locate returns a triangle that contains p, boundary included.
+func (c *cdt) locate(p vector2.Vector2) int {
+	t := c.last
+	if t < 0 || t >= len(c.tris) || c.tris[t].dead {
+		t = c.anyTriangle()
+	}
+	// Walk towards p, starting the edge scan at a varying edge so that the
+	// walk cannot cycle.
+	for step := 0; step < len(c.tris)+16; step++ {
+		v := c.tris[t].v
+		moved := false
+		for k := 0; k < 3; k++ {
+			i := (k + step) % 3
+			if orient(c.points[v[i]], c.points[v[(i+1)%3]], p) < 0 {
+				if n := c.neighbor(t, i); n >= 0 {
+					t = n
+					moved = true
+					break
+				}
+			}
+		}
+		if !moved {
+			return t
+		}
+	}
+	for i, tri := range c.tris {
+		if !tri.dead && c.containsPoint(i, p) {
+			return i
+		}
+	}
+	return t
+}
+
+func (c *cdt) anyTriangle() int {
+	for i := len(c.tris) - 1; i >= 0; i-- {
+		if !c.tris[i].dead {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *cdt) containsPoint(t int, p vector2.Vector2) bool {
+	v := c.tris[t].v
+	for i := 0; i < 3; i++ {
+		if orient(c.points[v[i]], c.points[v[(i+1)%3]], p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// boundaryEdge is an edge on the rim of an insertion cavity, with the inside
+// flag of the cavity triangle it belonged to.
+type boundaryEdge struct {
+	a, b   int
+	inside bool
+}
+
+// insertPoint adds p and restores the constrained Delaunay property. When
+// split names a constraint that p lies on, that constraint is replaced by its
+// two halves.
+func (c *cdt) insertPoint(p vector2.Vector2, split *[2]int) int {
+	cavity, boundary := c.cavity(p, c.locate(p), split)
+	return c.fillCavity(p, cavity, boundary, split)
+}
+
+// cavity collects the triangles that inserting p replaces: every triangle
+// whose circumcircle holds p and that p can reach from start without
+// crossing a constraint other than split.
+func (c *cdt) cavity(p vector2.Vector2, start int, split *[2]int) (map[int]bool, []boundaryEdge) {
+	cavity := map[int]bool{start: true}
+	stack := []int{start}
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		v := c.tris[t].v
+		for i := 0; i < 3; i++ {
+			n := c.neighbor(t, i)
+			if n < 0 || cavity[n] {
+				continue
+			}
+			key := edgeKey(v[i], v[(i+1)%3])
+			if c.constrained[key] && (split == nil || *split != key) {
+				continue
+			}
+			nv := c.tris[n].v
+			if inCircle(c.points[nv[0]], c.points[nv[1]], c.points[nv[2]], p) > 0 {
+				cavity[n] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+
+	var boundary []boundaryEdge
+	for t := range cavity {
+		v := c.tris[t].v
+		for i := 0; i < 3; i++ {
+			if n := c.neighbor(t, i); n < 0 || !cavity[n] {
+				boundary = append(boundary, boundaryEdge{v[i], v[(i+1)%3], c.tris[t].inside})
+			}
+		}
+	}
+	return cavity, boundary
+}
+
+// fillCavity replaces the cavity with a fan of triangles around the new
+// vertex p.
+func (c *cdt) fillCavity(p vector2.Vector2, cavity map[int]bool, boundary []boundaryEdge, split *[2]int) int {
+	idx := len(c.points)
+	c.points = append(c.points, p)
+	for t := range cavity {
+		c.removeTriangle(t)
+	}
+	for _, e := range boundary {
+		c.addTriangle(e.a, e.b, idx, e.inside)
+	}
+	if split != nil {
+		delete(c.constrained, *split)
+		c.constrained[edgeKey(split[0], idx)] = true
+		c.constrained[edgeKey(idx, split[1])] = true
+	}
+	return idx
+}
+
+// insertSegment makes ab an edge of the triangulation and marks it as a
+// constraint. Triangles crossed by the segment are removed and the two
+// pseudo-polygons on either side are retriangulated.
+func (c *cdt) insertSegment(a, b int) error {
+	if a == b {
+		return nil
+	}
+	if _, ok := c.edges[[2]int{a, b}]; ok {
+		c.constrained[edgeKey(a, b)] = true
+		return nil
+	}
+	if _, ok := c.edges[[2]int{b, a}]; ok {
+		c.constrained[edgeKey(a, b)] = true
+		return nil
+	}
+	pa, pb := c.points[a], c.points[b]
+
+	// Find the triangle at a whose opposite edge the segment leaves through.
+	start, left, right := -1, -1, -1
+	for t, tri := range c.tris {
+		if tri.dead {
+			continue
+		}
+		for i := 0; i < 3; i++ {
+			if tri.v[i] != a {
+				continue
+			}
+			u, w := tri.v[(i+1)%3], tri.v[(i+2)%3]
+			ou, ow := orient(pa, c.points[u], pb), orient(pa, c.points[w], pb)
+			// A vertex on the segment splits it in two.
+			if ou == 0 && c.points[u].Sub(pa).Dot(pb.Sub(pa)) > 0 {
+				return c.insertSegments(a, u, b)
+			}
+			if ow == 0 && c.points[w].Sub(pa).Dot(pb.Sub(pa)) > 0 {
+				return c.insertSegments(a, w, b)
+			}
+			if ou > 0 && ow < 0 {
+				start, right, left = t, u, w
+			}
+		}
+		if start >= 0 {
+			break
+		}
+	}
+	if start < 0 {
+		return ErrCrossingEdges
+	}
+
+	crossed := []int{start}
+	leftChain, rightChain := []int{left}, []int{right}
+	t := start
+	for {
+		if c.constrained[edgeKey(left, right)] {
+			return ErrCrossingEdges
+		}
+		n, ok := c.edges[[2]int{left, right}]
+		if !ok {
+			return ErrCrossingEdges
+		}
+		crossed = append(crossed, n)
+		t = n
+		x := c.opposite(t, left, right)
+		if x == b {
+			break
+		}
+		o := orient(pa, pb, c.points[x])
+		switch {
+		case o == 0:
+			return c.insertSegments(a, x, b)
+		case o > 0:
+			leftChain = append(leftChain, x)
+			left = x
+		default:
+			rightChain = append(rightChain, x)
+			right = x
+		}
+	}
+
+	for _, t := range crossed {
+		c.removeTriangle(t)
+	}
+	c.fillPseudoPolygon(a, b, dedupe(leftChain))
+	c.fillPseudoPolygon(a, b, dedupe(rightChain))
+	c.constrained[edgeKey(a, b)] = true
+	return nil
+}
+
+func (c *cdt) insertSegments(a, mid, b int) error {
+	if err := c.insertSegment(a, mid); err != nil {
+		return err
+	}
+	return c.insertSegment(mid, b)
+}
+
+// opposite returns the vertex of t that is neither a nor b.
+func (c *cdt) opposite(t, a, b int) int {
+	for _, v := range c.tris[t].v {
+		if v != a && v != b {
+			return v
+		}
+	}
+	return -1
+}
+
+func dedupe(chain []int) []int {
+	out := chain[:0]
+	seen := map[int]bool{}
+	for _, v := range chain {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// fillPseudoPolygon triangulates the polygon a, chain..., b, picking for
+// edge ab the chain vertex whose circle through a and b holds no other chain
+// vertex and recursing on either side of it.
+func (c *cdt) fillPseudoPolygon(a, b int, chain []int) {
+	if len(chain) == 0 {
+		return
+	}
+	best := 0
+	for i := 1; i < len(chain); i++ {
+		pa, pb, pc := c.points[a], c.points[b], c.points[chain[best]]
+		if orient(pa, pb, pc) < 0 {
+			pa, pb = pb, pa
+		}
+		if inCircle(pa, pb, pc, c.points[chain[i]]) > 0 {
+			best = i
+		}
+	}
+	v := chain[best]
+	c.fillPseudoPolygon(a, v, chain[:best])
+	c.fillPseudoPolygon(v, b, chain[best+1:])
+	c.addTriangle(a, b, v, false)
+}
+
+// classify marks the triangles enclosed by the constraints with the
+// even-odd rule, flooding outwards from the super triangle and counting
+// crossed constraints.
+func (c *cdt) classify() {
+	depth := make([]int, len(c.tris))
+	for i := range depth {
+		depth[i] = -1
+	}
+	var current, next []int
+	for t, tri := range c.tris {
+		if !tri.dead && (tri.v[0] < superVertices || tri.v[1] < superVertices || tri.v[2] < superVertices) {
+			depth[t] = 0
+			current = append(current, t)
+		}
+	}
+	for level := 0; len(current) > 0; level++ {
+		for len(current) > 0 {
+			t := current[len(current)-1]
+			current = current[:len(current)-1]
+			c.tris[t].inside = level%2 == 1
+			v := c.tris[t].v
+			for i := 0; i < 3; i++ {
+				n := c.neighbor(t, i)
+				if n < 0 || depth[n] >= 0 {
+					continue
+				}
+				if c.constrained[edgeKey(v[i], v[(i+1)%3])] {
+					continue
+				}
+				depth[n] = level
+				current = append(current, n)
+			}
+		}
+		// Step across the constraints bounding this level.
+		for t, tri := range c.tris {
+			if tri.dead || depth[t] != level {
+				continue
+			}
+			for i := 0; i < 3; i++ {
+				n := c.neighbor(t, i)
+				if n >= 0 && depth[n] < 0 {
+					depth[n] = level + 1
+					next = append(next, n)
+				}
+			}
+		}
+		current, next = next, nil
+	}
+}
+
+// result returns the inside triangles, dropping the super triangle vertices.
+func (c *cdt) result() Triangulation {
+	out := Triangulation{Vertices: append([]vector2.Vector2{}, c.points[superVertices:]...)}
+	for _, tri := range c.tris {
+		if tri.dead || !tri.inside {
+			continue
+		}
+		out.Triangles = append(out.Triangles, [3]int{
+			tri.v[0] - superVertices, tri.v[1] - superVertices, tri.v[2] - superVertices,
+		})
+	}
+	return out
+}
+
+// Constrained returns a constrained Delaunay triangulation of the area
+// enclosed by the rings. Every ring edge appears in the result, the area is
+// read with the even-odd rule so that nested rings form holes, and no
+// triangle has another vertex inside its circumcircle unless a ring edge
+// separates them. Rings must not cross each other. With refinement options
+// set, Steiner points are added until every triangle meets them.
+func Constrained(rings [][]vector2.Vector2, opts Options) (Triangulation, error) {
+	var points []vector2.Vector2
+	for _, ring := range rings {
+		points = append(points, ring...)
+	}
+	if len(points) < 3 {
+		return Triangulation{}, nil
+	}
+	c := newCDT(points)
+	index := map[vector2.Vector2]int{}
+	for _, i := range insertionOrder(len(points)) {
+		if _, ok := index[points[i]]; !ok {
+			index[points[i]] = c.insertPoint(points[i], nil)
+		}
+	}
+	ringIndices := make([][]int, len(rings))
+	for r, ring := range rings {
+		for _, p := range ring {
+			i := index[p]
+			if n := len(ringIndices[r]); n == 0 || ringIndices[r][n-1] != i {
+				ringIndices[r] = append(ringIndices[r], i)
+			}
+		}
+	}
+	for _, ring := range ringIndices {
+		n := len(ring)
+		if n > 1 && ring[0] == ring[n-1] {
+			ring = ring[:n-1]
+			n--
+		}
+		if n < 2 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			if err := c.insertSegment(ring[i], ring[(i+1)%n]); err != nil {
+				return Triangulation{}, err
+			}
+		}
+	}
+	c.classify()
+	if opts.MinAngle > 0 || opts.MaxArea > 0 {
+		c.refine(opts)
+	}
+	return c.result(), nil
+}
@@ -0,0 +1,142 @@
+package triangulate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// squareWithHole returns a 20 by 20 outline with a 6 by 6 hole and a long
+// sliver hole whose edges cut through the Delaunay triangulation of the rest.
+func squareWithHole() [][]vector2.Vector2 {
+	return [][]vector2.Vector2{
+		{{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 20}, {X: 0, Y: 20}},
+		{{X: 4, Y: 4}, {X: 4, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 4}},
+		{{X: 1, Y: 12}, {X: 19, Y: 13}, {X: 1, Y: 14}},
+	}
+}
+
+func edgeSet(tri Triangulation) map[[2]vector2.Vector2]bool {
+	edges := map[[2]vector2.Vector2]bool{}
+	for _, v := range tri.Triangles {
+		for i := 0; i < 3; i++ {
+			a, b := tri.Vertices[v[i]], tri.Vertices[v[(i+1)%3]]
+			edges[[2]vector2.Vector2{a, b}] = true
+			edges[[2]vector2.Vector2{b, a}] = true
+		}
+	}
+	return edges
+}
+
+// checkCover checks that the triangles are counter-clockwise, cover the
+// outline less its holes, and keep out of the holes.
+func checkCover(t *testing.T, tri Triangulation, rings [][]vector2.Vector2) {
+	t.Helper()
+	want := math.Abs(ringArea(rings[0]))
+	for _, hole := range rings[1:] {
+		want -= math.Abs(ringArea(hole))
+	}
+	total := 0.0
+	for _, v := range tri.Triangles {
+		a, b, c := tri.Vertices[v[0]], tri.Vertices[v[1]], tri.Vertices[v[2]]
+		area := orient(a, b, c) / 2
+		if area <= 0 {
+			t.Fatalf("triangle %v is not counter-clockwise", v)
+		}
+		total += area
+		center := a.Add(b).Add(c).Divf(3)
+		for _, hole := range rings[1:] {
+			if insideRing(hole, center) {
+				t.Errorf("triangle %v lies in the hole %v", v, hole)
+			}
+		}
+	}
+	if math.Abs(total-want) > 1e-9 {
+		t.Errorf("triangles cover %g, want %g", total, want)
+	}
+}
+
+func insideRing(ring []vector2.Vector2, p vector2.Vector2) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func TestConstrained(t *testing.T) {
+	rings := squareWithHole()
+	tri, err := Constrained(rings, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tri.Vertices) != 11 {
+		t.Errorf("got %d vertices, want the 11 input points", len(tri.Vertices))
+	}
+	checkCover(t, tri, rings)
+	edges := edgeSet(tri)
+	for _, ring := range rings {
+		for i := range ring {
+			if e := [2]vector2.Vector2{ring[i], ring[(i+1)%len(ring)]}; !edges[e] {
+				t.Errorf("constraint edge %v is missing", e)
+			}
+		}
+	}
+
+	crossing := [][]vector2.Vector2{
+		{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}},
+		{{X: -1, Y: 5}, {X: 11, Y: 5}, {X: 5, Y: 6}},
+	}
+	if _, err := Constrained(crossing, Options{}); err != ErrCrossingEdges {
+		t.Errorf("got error %v for crossing rings, want ErrCrossingEdges", err)
+	}
+}
+
+func TestConstrainedRefine(t *testing.T) {
+	rings := squareWithHole()
+	opts := Options{MinAngle: 20 * math.Pi / 180, MaxArea: 2}
+	tri, err := Constrained(rings, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCover(t, tri, rings)
+	for _, v := range tri.Triangles {
+		a, b, c := tri.Vertices[v[0]], tri.Vertices[v[1]], tri.Vertices[v[2]]
+		if area := orient(a, b, c) / 2; area > opts.MaxArea {
+			t.Errorf("triangle %v has area %g, above %g", v, area, opts.MaxArea)
+		}
+		if angle := minAngle(a, b, c); angle < opts.MinAngle-1e-9 {
+			t.Errorf("triangle %v has an angle of %g°, below 20°", v, angle*180/math.Pi)
+		}
+	}
+
+	// Every constraint edge is still covered, split into collinear pieces.
+	edges := edgeSet(tri)
+	for _, ring := range rings {
+		for i := range ring {
+			a, b := ring[i], ring[(i+1)%len(ring)]
+			length := 0.0
+			for e := range edges {
+				if onSegment(e[0], a, b) && onSegment(e[1], a, b) {
+					length += e[0].DistanceTo(e[1]) / 2
+				}
+			}
+			if math.Abs(length-a.DistanceTo(b)) > 1e-9 {
+				t.Errorf("constraint edge %v to %v is covered for %g of %g", a, b, length, a.DistanceTo(b))
+			}
+		}
+	}
+}
+
+func onSegment(p, a, b vector2.Vector2) bool {
+	ab, ap := b.Sub(a), p.Sub(a)
+	if math.Abs(ab.Cross(ap)) > 1e-9*ab.Length() {
+		return false
+	}
+	d := ap.Dot(ab)
+	return d >= -1e-9 && d <= ab.Dot(ab)+1e-9
+}
@@ -0,0 +1,103 @@
+package triangulate
+
+import (
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/hull"
+)
+
+// Delaunay returns the Delaunay triangulation of a point set, covering its
+// convex hull. Vertices holds the points as given and triangle indices refer
+// to them; a repeated point is represented by its first occurrence. Fewer
+// than three points, or points that are all collinear, give no triangles.
+func Delaunay(points []vector2.Vector2) Triangulation {
+	out := Triangulation{Vertices: append([]vector2.Vector2{}, points...)}
+	boundary := hull.MonotoneChain(points)
+	if len(boundary) < 3 {
+		return out
+	}
+
+	c := newCDT(points)
+	first := map[vector2.Vector2]int{}
+	for i := len(points) - 1; i >= 0; i-- {
+		first[points[i]] = i
+	}
+	index := map[vector2.Vector2]int{}
+	input := []int{}
+	for _, i := range insertionOrder(len(points)) {
+		if _, ok := index[points[i]]; ok {
+			continue
+		}
+		index[points[i]] = c.insertPoint(points[i], nil)
+		input = append(input, first[points[i]])
+	}
+	// Hull edges are Delaunay edges, so constraining them only separates the
+	// triangles inside the hull from those attached to the super triangle.
+	for i := range boundary {
+		a, b := index[boundary[i]], index[boundary[(i+1)%len(boundary)]]
+		if err := c.insertSegment(a, b); err != nil {
+			return out
+		}
+	}
+	c.classify()
+
+	for _, tri := range c.tris {
+		if tri.dead || !tri.inside {
+			continue
+		}
+		out.Triangles = append(out.Triangles, [3]int{
+			input[tri.v[0]-superVertices], input[tri.v[1]-superVertices], input[tri.v[2]-superVertices],
+		})
+	}
+	return out
+}
+
+// Adjacency returns, for every vertex, the sorted indices of the vertices it
+// shares a triangle edge with.
+func (t Triangulation) Adjacency() [][]int {
+	sets := make([]map[int]bool, len(t.Vertices))
+	for _, tri := range t.Triangles {
+		for i := 0; i < 3; i++ {
+			a, b := tri[i], tri[(i+1)%3]
+			if sets[a] == nil {
+				sets[a] = map[int]bool{}
+			}
+			if sets[b] == nil {
+				sets[b] = map[int]bool{}
+			}
+			sets[a][b] = true
+			sets[b][a] = true
+		}
+	}
+	adjacency := make([][]int, len(t.Vertices))
+	for v, set := range sets {
+		for n := range set {
+			adjacency[v] = append(adjacency[v], n)
+		}
+		sort.Ints(adjacency[v])
+	}
+	return adjacency
+}
+
+// Neighbors returns, for every triangle, the triangles across its three
+// edges: entry i is across the edge from vertex i to vertex i+1, or -1 on
+// the boundary.
+func (t Triangulation) Neighbors() [][3]int {
+	owner := make(map[[2]int]int, 3*len(t.Triangles))
+	for n, tri := range t.Triangles {
+		for i := 0; i < 3; i++ {
+			owner[[2]int{tri[i], tri[(i+1)%3]}] = n
+		}
+	}
+	neighbors := make([][3]int, len(t.Triangles))
+	for n, tri := range t.Triangles {
+		for i := 0; i < 3; i++ {
+			neighbors[n][i] = -1
+			if m, ok := owner[[2]int{tri[(i+1)%3], tri[i]}]; ok {
+				neighbors[n][i] = m
+			}
+		}
+	}
+	return neighbors
+}
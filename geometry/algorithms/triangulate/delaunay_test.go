@@ -0,0 +1,44 @@
+package triangulate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/hull"
+)
+
+func TestDelaunay(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]vector2.Vector2, 200)
+	for i := range points {
+		points[i] = vector2.Vector2{X: r.Float64() * 100, Y: r.Float64() * 100}
+	}
+	tri := Delaunay(points)
+
+	boundary := hull.MonotoneChain(points)
+	if want := 2*len(points) - 2 - len(boundary); len(tri.Triangles) != want {
+		t.Errorf("got %d triangles, want %d", len(tri.Triangles), want)
+	}
+	total := 0.0
+	for _, v := range tri.Triangles {
+		a, b, c := points[v[0]], points[v[1]], points[v[2]]
+		if orient(a, b, c) <= 0 {
+			t.Fatalf("triangle %v is not counter-clockwise", v)
+		}
+		total += orient(a, b, c) / 2
+		for i, p := range points {
+			if i != v[0] && i != v[1] && i != v[2] && inCircle(a, b, c, p) > 1e-9 {
+				t.Fatalf("point %d lies inside the circumcircle of %v", i, v)
+			}
+		}
+	}
+	if want := math.Abs(ringArea(boundary)); math.Abs(total-want) > 1e-6 {
+		t.Errorf("triangles cover %g, want the hull area %g", total, want)
+	}
+
+	if got := Delaunay([]vector2.Vector2{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}}); len(got.Triangles) != 0 {
+		t.Errorf("got %d triangles for collinear points, want none", len(got.Triangles))
+	}
+}
@@ -0,0 +1,123 @@
+package triangulate
+
+import (
+	"errors"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// ErrNotSimple is returned when a polygon crosses itself and no ear can be
+// found.
+var ErrNotSimple = errors.New("triangulate: polygon is not simple")
+
+// EarClip triangulates a simple polygon, without holes, by repeatedly cutting
+// off convex corners that contain no other vertex. Whether a corner is an ear
+// is cached and only rechecked for the two neighbours of a cut, so it runs in
+// O(n²). Triangles are returned as indices into polygon. Either winding is
+// accepted.
+func EarClip(polygon []vector2.Vector2) ([][3]int, error) {
+	n := len(polygon)
+	if n < 3 {
+		return nil, nil
+	}
+	area := 0.0
+	for i := range polygon {
+		area += orient(vector2.Vector2{}, polygon[i], polygon[(i+1)%n])
+	}
+	// Link the vertices counter-clockwise.
+	c := earList{polygon: polygon, prev: make([]int, n), next: make([]int, n), ear: make([]bool, n)}
+	for i := range polygon {
+		if area >= 0 {
+			c.prev[i], c.next[i] = (i+n-1)%n, (i+1)%n
+		} else {
+			c.prev[i], c.next[i] = (i+1)%n, (i+n-1)%n
+		}
+	}
+	c.remaining = n
+	c.updateAll(0)
+
+	triangles := make([][3]int, 0, n-2)
+	v, stalled := 0, 0
+	for c.remaining > 3 {
+		if c.ear[v] {
+			triangles = append(triangles, [3]int{c.prev[v], v, c.next[v]})
+			v = c.remove(v)
+			stalled = 0
+			continue
+		}
+		v = c.next[v]
+		if stalled++; stalled < c.remaining {
+			continue
+		}
+		// A full turn without an ear. Recheck every corner once in case a
+		// cached answer is stale, then drop a corner without area, which
+		// collinear runs leave behind.
+		stalled = 0
+		if c.updateAll(v) {
+			continue
+		}
+		flat := -1
+		for u, k := v, 0; k < c.remaining; u, k = c.next[u], k+1 {
+			if orient(polygon[c.prev[u]], polygon[u], polygon[c.next[u]]) == 0 {
+				flat = u
+				break
+			}
+		}
+		if flat < 0 {
+			return triangles, ErrNotSimple
+		}
+		v = c.remove(flat)
+	}
+	if orient(polygon[c.prev[v]], polygon[v], polygon[c.next[v]]) > 0 {
+		triangles = append(triangles, [3]int{c.prev[v], v, c.next[v]})
+	}
+	return triangles, nil
+}
+
+// earList holds the remaining corners of an ear clipping as a circular
+// doubly linked list over the polygon's vertex indices.
+type earList struct {
+	polygon    []vector2.Vector2
+	prev, next []int
+	ear        []bool
+	remaining  int
+}
+
+// remove unlinks corner v, rechecks its neighbours and returns the next one.
+func (l *earList) remove(v int) int {
+	p, q := l.prev[v], l.next[v]
+	l.next[p], l.prev[q] = q, p
+	l.remaining--
+	l.ear[p], l.ear[q] = l.isEar(p), l.isEar(q)
+	return q
+}
+
+// updateAll rechecks every remaining corner, starting from v, and reports
+// whether any is an ear.
+func (l *earList) updateAll(v int) bool {
+	found := false
+	for u, k := v, 0; k < l.remaining; u, k = l.next[u], k+1 {
+		l.ear[u] = l.isEar(u)
+		found = found || l.ear[u]
+	}
+	return found
+}
+
+// isEar reports whether corner v is convex and its triangle holds no other
+// remaining vertex.
+func (l *earList) isEar(v int) bool {
+	a, b, d := l.polygon[l.prev[v]], l.polygon[v], l.polygon[l.next[v]]
+	if orient(a, b, d) <= 0 {
+		return false
+	}
+	for u := l.next[l.next[v]]; u != l.prev[v]; u = l.next[u] {
+		p := l.polygon[u]
+		if p == a || p == b || p == d {
+			continue
+		}
+		if orient(a, b, p) >= 0 && orient(b, d, p) >= 0 && orient(d, a, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package triangulate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// comb returns a counter-clockwise polygon with teeth deep notches, which
+// leaves few ears at every step.
+func comb(teeth int) []vector2.Vector2 {
+	var polygon []vector2.Vector2
+	for i := 0; i < teeth; i++ {
+		x := float64(i) * 2
+		polygon = append(polygon, vector2.Vector2{X: x, Y: 0}, vector2.Vector2{X: x + 1, Y: 0.5})
+	}
+	polygon = append(polygon, vector2.Vector2{X: float64(teeth) * 2, Y: 0}, vector2.Vector2{X: float64(teeth) * 2, Y: 10}, vector2.Vector2{X: 0, Y: 10})
+	return polygon
+}
+
+func ringArea(ring []vector2.Vector2) float64 {
+	area := 0.0
+	for i := range ring {
+		area += orient(vector2.Vector2{}, ring[i], ring[(i+1)%len(ring)])
+	}
+	return area / 2
+}
+
+func TestEarClip(t *testing.T) {
+	square := []vector2.Vector2{{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}
+	for _, tt := range []struct {
+		name    string
+		polygon []vector2.Vector2
+	}{
+		{"comb", comb(20)},
+		{"clockwise comb", reversed(comb(20))},
+		{"square with a collinear vertex", square},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			triangles, err := EarClip(tt.polygon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(triangles) != len(tt.polygon)-2 {
+				t.Errorf("got %d triangles, want %d", len(triangles), len(tt.polygon)-2)
+			}
+			total := 0.0
+			for _, tri := range triangles {
+				area := orient(tt.polygon[tri[0]], tt.polygon[tri[1]], tt.polygon[tri[2]]) / 2
+				if area < 0 {
+					t.Errorf("triangle %v is clockwise", tri)
+				}
+				total += area
+			}
+			if want := math.Abs(ringArea(tt.polygon)); math.Abs(total-want) > 1e-9 {
+				t.Errorf("triangles cover %g, want %g", total, want)
+			}
+		})
+	}
+
+}
+
+func reversed(polygon []vector2.Vector2) []vector2.Vector2 {
+	out := make([]vector2.Vector2, len(polygon))
+	for i, p := range polygon {
+		out[len(polygon)-1-i] = p
+	}
+	return out
+}
@@ -0,0 +1,234 @@
+package triangulate
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// DefaultMaxSteiner bounds the number of points refinement may add when
+// Options.MaxSteiner is zero.
+const DefaultMaxSteiner = 100000
+
+// Options controls Delaunay refinement. Zero values disable each limit.
+type Options struct {
+	// MinAngle is the smallest interior angle, in radians, a triangle may
+	// have. Refinement is guaranteed to finish for angles up to about 0.36
+	// (20.7°) and usually does up to 0.59 (33.8°); larger requests are cut
+	// short by MaxSteiner.
+	MinAngle float64
+	// MaxArea is the largest area a triangle may have.
+	MaxArea float64
+	// MaxSteiner caps the number of points added.
+	MaxSteiner int
+}
+
+// refine inserts Steiner points in the style of Ruppert's algorithm: a
+// constraint segment with a vertex inside its diametral circle is split (see
+// splitSegment), and a triangle that breaks the limits gets a vertex at its
+// circumcenter, unless that point would encroach a segment on the rim of its
+// insertion cavity, in which case those segments are split instead.
+func (c *cdt) refine(opts Options) {
+	r := refiner{cdt: c, budget: opts.MaxSteiner, origin: map[int][2]int{}}
+	if r.budget <= 0 {
+		r.budget = DefaultMaxSteiner
+	}
+	for _, key := range c.segments() {
+		if c.segmentEncroached(key) {
+			r.segments = append(r.segments, key)
+		}
+	}
+	r.splitEncroached()
+
+	queue := make([]int, 0, len(c.tris))
+	for t := range c.tris {
+		queue = append(queue, t)
+	}
+	for len(queue) > 0 && r.added < r.budget {
+		t := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if c.tris[t].dead || !c.tris[t].inside || !r.bad(t, opts) {
+			continue
+		}
+		v := c.tris[t].v
+		center := circumcenter(c.points[v[0]], c.points[v[1]], c.points[v[2]])
+		at := c.locate(center)
+		if !c.tris[at].inside || !c.containsPoint(at, center) {
+			continue
+		}
+
+		c.created = c.created[:0]
+		cavity, boundary := c.cavity(center, at, nil)
+		for _, e := range boundary {
+			key := edgeKey(e.a, e.b)
+			if c.constrained[key] && encroaches(c.points[e.a], c.points[e.b], center) {
+				r.segments = append(r.segments, key)
+			}
+		}
+		if len(r.segments) > 0 {
+			r.splitEncroached()
+			queue = append(queue, t)
+		} else {
+			c.fillCavity(center, cavity, boundary, nil)
+			r.added++
+		}
+		queue = append(queue, c.created...)
+	}
+}
+
+// refiner tracks the Steiner point budget and pending segment splits.
+type refiner struct {
+	*cdt
+	budget, added int
+	segments      [][2]int
+	// origin maps each vertex added on a constraint to the input segment it
+	// lies on.
+	origin map[int][2]int
+}
+
+// splitEncroached splits queued segments, and any segment a new midpoint
+// encroaches in turn, until none is left or the budget runs out.
+func (r *refiner) splitEncroached() {
+	for len(r.segments) > 0 && r.added < r.budget {
+		key := r.segments[len(r.segments)-1]
+		r.segments = r.segments[:len(r.segments)-1]
+		if !r.constrained[key] {
+			continue
+		}
+		start := len(r.created)
+		r.splitSegment(key)
+		r.added++
+		// Check the constraints on the rim of the new vertex's star.
+		for _, t := range r.created[start:] {
+			if r.tris[t].dead {
+				continue
+			}
+			v := r.tris[t].v
+			for i := 0; i < 3; i++ {
+				k := edgeKey(v[i], v[(i+1)%3])
+				if r.constrained[k] && r.segmentEncroached(k) {
+					r.segments = append(r.segments, k)
+				}
+			}
+		}
+	}
+	r.segments = r.segments[:0]
+}
+
+// bad reports whether triangle t breaks the refinement limits.
+func (r *refiner) bad(t int, opts Options) bool {
+	v := r.tris[t].v
+	a, b, d := r.points[v[0]], r.points[v[1]], r.points[v[2]]
+	if opts.MaxArea > 0 && orient(a, b, d)/2 > opts.MaxArea {
+		return true
+	}
+	if opts.MinAngle <= 0 || minAngle(a, b, d) >= opts.MinAngle {
+		return false
+	}
+	return !r.shellTriangle(v)
+}
+
+// shellTriangle reports whether the shortest edge of a triangle joins two
+// points at the same distance from an input vertex along two different
+// input segments. Such triangles sit inside a small input angle, which no
+// amount of refinement can widen, so they are left alone.
+func (r *refiner) shellTriangle(v [3]int) bool {
+	shortest := 0
+	for i := 1; i < 3; i++ {
+		if r.points[v[i]].DistanceSquaredTo(r.points[v[(i+1)%3]]) < r.points[v[shortest]].DistanceSquaredTo(r.points[v[(shortest+1)%3]]) {
+			shortest = i
+		}
+	}
+	p, q := v[shortest], v[(shortest+1)%3]
+	sp, okP := r.origin[p]
+	sq, okQ := r.origin[q]
+	if !okP || !okQ || sp == sq {
+		return false
+	}
+	for _, apex := range sp {
+		if apex != sq[0] && apex != sq[1] {
+			continue
+		}
+		dp := r.points[apex].DistanceTo(r.points[p])
+		dq := r.points[apex].DistanceTo(r.points[q])
+		if math.Abs(dp-dq) <= 1e-9*math.Max(dp, dq) {
+			return true
+		}
+	}
+	return false
+}
+
+func minAngle(a, b, c vector2.Vector2) float64 {
+	angle := func(p, q, r vector2.Vector2) float64 {
+		u, w := q.Sub(p), r.Sub(p)
+		return math.Abs(math.Atan2(u.Cross(w), u.Dot(w)))
+	}
+	return math.Min(angle(a, b, c), math.Min(angle(b, c, a), angle(c, a, b)))
+}
+
+// splitSegment splits a constraint segment. A piece that runs from an end
+// of its input segment is split at a power of two distance from that end
+// (concentric shells), so that pieces of segments meeting at a small angle
+// line up and stop encroaching on each other; other pieces are split at
+// their midpoint.
+func (r *refiner) splitSegment(key [2]int) {
+	segment := key
+	if s, ok := r.origin[key[0]]; ok {
+		segment = s
+	} else if s, ok := r.origin[key[1]]; ok {
+		segment = s
+	}
+	a, b := r.points[key[0]], r.points[key[1]]
+	split := a.Add(b).Divf(2)
+	aEnd := key[0] == segment[0] || key[0] == segment[1]
+	bEnd := key[1] == segment[0] || key[1] == segment[1]
+	if aEnd != bEnd {
+		apex, other := a, b
+		if bEnd {
+			apex, other = b, a
+		}
+		length := apex.DistanceTo(other)
+		shell := math.Exp2(math.Round(math.Log2(length / 2)))
+		split = apex.Add(other.Sub(apex).Mulf(shell / length))
+	}
+	idx := r.insertPoint(split, &key)
+	r.origin[idx] = segment
+}
+
+// segmentEncroached reports whether a vertex facing the segment lies inside
+// its diametral circle.
+func (c *cdt) segmentEncroached(key [2]int) bool {
+	for _, e := range [][2]int{{key[0], key[1]}, {key[1], key[0]}} {
+		t, ok := c.edges[e]
+		if !ok {
+			continue
+		}
+		if encroaches(c.points[key[0]], c.points[key[1]], c.points[c.opposite(t, key[0], key[1])]) {
+			return true
+		}
+	}
+	return false
+}
+
+// segments returns the constraint segments in a fixed order, so that
+// refinement is deterministic.
+func (c *cdt) segments() [][2]int {
+	keys := make([][2]int, 0, len(c.constrained))
+	for key := range c.constrained {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+// encroaches reports whether p lies strictly inside the circle with
+// diameter ab.
+func encroaches(a, b, p vector2.Vector2) bool {
+	return p.Sub(a).Dot(p.Sub(b)) < 0
+}
@@ -0,0 +1,50 @@
+package triangulate
+
+import (
+	"math/rand"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// Triangulation is an indexed triangle mesh. Every triangle lists three
+// indices into Vertices in counter-clockwise order (positive signed area in a
+// y-up frame).
+type Triangulation struct {
+	Vertices  []vector2.Vector2
+	Triangles [][3]int
+}
+
+// orient returns twice the signed area of triangle abc: positive when a, b, c
+// turn counter-clockwise.
+func orient(a, b, c vector2.Vector2) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// inCircle is positive when d lies inside the circumcircle of the
+// counter-clockwise triangle abc.
+func inCircle(a, b, c, d vector2.Vector2) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+	ad := adx*adx + ady*ady
+	bd := bdx*bdx + bdy*bdy
+	cd := cdx*cdx + cdy*cdy
+	return adx*(bdy*cd-bd*cdy) - ady*(bdx*cd-bd*cdx) + ad*(bdx*cdy-bdy*cdx)
+}
+
+// circumcenter returns the center of the circle through a, b and c.
+func circumcenter(a, b, c vector2.Vector2) vector2.Vector2 {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	return vector2.Vector2{X: a.X + (cy*b2-by*c2)/d, Y: a.Y + (bx*c2-cx*b2)/d}
+}
+
+// insertionOrder returns a fixed pseudo-random permutation of n indices.
+// Inserting points in random order keeps the expected cavity size constant,
+// where points that arrive in order along a line would each replace a
+// growing fan of triangles.
+func insertionOrder(n int) []int {
+	return rand.New(rand.NewSource(1)).Perm(n)
+}
@@ -0,0 +1,172 @@
+package voronoi
+
+import (
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/triangulate"
+)
+
+// Diagram is the Voronoi diagram of a set of sites together with its dual
+// Delaunay triangulation. The cell of a site is the set of points closer to
+// it than to any other site.
+type Diagram struct {
+	Sites []vector2.Vector2
+	// Delaunay triangulates the sites; its triangle indices refer to Sites.
+	Delaunay triangulate.Triangulation
+	// Neighbors lists, for every site, the sorted indices of the sites whose
+	// cells share an edge with its cell. Repeated sites have no neighbors.
+	Neighbors [][]int
+	// Vertices are the Voronoi vertices: the circumcenter of every Delaunay
+	// triangle, in the order of Delaunay.Triangles.
+	Vertices []vector2.Vector2
+	// duplicate marks sites that repeat an earlier site.
+	duplicate []bool
+}
+
+// New computes the Voronoi diagram of the sites.
+func New(sites []vector2.Vector2) Diagram {
+	d := Diagram{
+		Sites:     append([]vector2.Vector2{}, sites...),
+		Delaunay:  triangulate.Delaunay(sites),
+		duplicate: make([]bool, len(sites)),
+	}
+	seen := map[vector2.Vector2]bool{}
+	for i, s := range sites {
+		d.duplicate[i] = seen[s]
+		seen[s] = true
+	}
+	for _, t := range d.Delaunay.Triangles {
+		d.Vertices = append(d.Vertices, circumcenter(sites[t[0]], sites[t[1]], sites[t[2]]))
+	}
+
+	if len(d.Delaunay.Triangles) > 0 {
+		d.Neighbors = d.Delaunay.Adjacency()
+		return d
+	}
+	// Collinear sites have no triangles; their cells are parallel strips
+	// between consecutive sites along the line.
+	d.Neighbors = make([][]int, len(sites))
+	var order []int
+	for i := range sites {
+		if !d.duplicate[i] {
+			order = append(order, i)
+		}
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := sites[order[i]], sites[order[j]]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return a.Y < b.Y
+	})
+	for k := 1; k < len(order); k++ {
+		a, b := order[k-1], order[k]
+		d.Neighbors[a] = append(d.Neighbors[a], b)
+		d.Neighbors[b] = append(d.Neighbors[b], a)
+	}
+	for i := range d.Neighbors {
+		sort.Ints(d.Neighbors[i])
+	}
+	return d
+}
+
+// Cell returns the cell of site i clipped to a convex bounding polygon,
+// counter-clockwise. The cell is empty for repeated sites and for sites
+// whose cell misses the bounds.
+func (d Diagram) Cell(i int, bounds []vector2.Vector2) []vector2.Vector2 {
+	if i < 0 || i >= len(d.Sites) || d.duplicate[i] {
+		return nil
+	}
+	cell := append([]vector2.Vector2{}, bounds...)
+	if signedArea(cell) < 0 {
+		for l, r := 0, len(cell)-1; l < r; l, r = l+1, r-1 {
+			cell[l], cell[r] = cell[r], cell[l]
+		}
+	}
+	site := d.Sites[i]
+	for _, n := range d.Neighbors[i] {
+		normal := d.Sites[n].Sub(site)
+		mid := site.Add(d.Sites[n]).Divf(2)
+		cell = clipHalfPlane(cell, mid, normal)
+		if len(cell) == 0 {
+			return nil
+		}
+	}
+	if len(cell) < 3 {
+		return nil
+	}
+	return cell
+}
+
+// Cells returns the cell of every site clipped to a convex bounding
+// polygon, in the order of Sites.
+func (d Diagram) Cells(bounds []vector2.Vector2) [][]vector2.Vector2 {
+	cells := make([][]vector2.Vector2, len(d.Sites))
+	for i := range d.Sites {
+		cells[i] = d.Cell(i, bounds)
+	}
+	return cells
+}
+
+// Nearest returns the index of the site closest to p, or -1 without sites.
+// The search walks the Delaunay graph from site start, which should be a
+// site near p when queries are spatially coherent; any start is correct.
+func (d Diagram) Nearest(p vector2.Vector2, start int) int {
+	if len(d.Sites) == 0 {
+		return -1
+	}
+	if start < 0 || start >= len(d.Sites) || d.duplicate[start] {
+		start = 0
+	}
+	current := start
+	best := d.Sites[current].DistanceSquaredTo(p)
+	for {
+		next := current
+		for _, n := range d.Neighbors[current] {
+			if dist := d.Sites[n].DistanceSquaredTo(p); dist < best {
+				best = dist
+				next = n
+			}
+		}
+		if next == current {
+			return current
+		}
+		current = next
+	}
+}
+
+// clipHalfPlane keeps the part of a convex polygon on the side of the line
+// through point that normal points away from (Sutherland–Hodgman).
+func clipHalfPlane(polygon []vector2.Vector2, point, normal vector2.Vector2) []vector2.Vector2 {
+	var out []vector2.Vector2
+	n := len(polygon)
+	for k := 0; k < n; k++ {
+		a, b := polygon[k], polygon[(k+1)%n]
+		da, db := a.Sub(point).Dot(normal), b.Sub(point).Dot(normal)
+		if da <= 0 {
+			out = append(out, a)
+		}
+		if (da < 0 && db > 0) || (da > 0 && db < 0) {
+			out = append(out, a.Add(b.Sub(a).Mulf(da/(da-db))))
+		}
+	}
+	return out
+}
+
+func circumcenter(a, b, c vector2.Vector2) vector2.Vector2 {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	return vector2.Vector2{X: a.X + (cy*b2-by*c2)/d, Y: a.Y + (bx*c2-cx*b2)/d}
+}
+
+func signedArea(polygon []vector2.Vector2) float64 {
+	area := 0.0
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		area += a.Cross(b)
+	}
+	return area / 2
+}
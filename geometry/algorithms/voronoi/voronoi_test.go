@@ -0,0 +1,88 @@
+package voronoi
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func randomSites(n int) []vector2.Vector2 {
+	r := rand.New(rand.NewSource(1))
+	sites := make([]vector2.Vector2, n)
+	for i := range sites {
+		sites[i] = vector2.Vector2{X: r.Float64() * 100, Y: r.Float64() * 100}
+	}
+	return sites
+}
+
+func TestCells(t *testing.T) {
+	sites := randomSites(100)
+	d := New(sites)
+	bounds := []vector2.Vector2{{X: -10, Y: -10}, {X: 110, Y: -10}, {X: 110, Y: 110}, {X: -10, Y: 110}}
+
+	total := 0.0
+	for i, cell := range d.Cells(bounds) {
+		area := signedArea(cell)
+		if area <= 0 {
+			t.Fatalf("cell %d has area %g, want a counter-clockwise cell", i, area)
+		}
+		total += area
+		// Every point of a cell is at least as close to its site as to any
+		// other, so its corners are too.
+		for _, v := range cell {
+			own := v.DistanceTo(sites[i])
+			for j, s := range sites {
+				if v.DistanceTo(s) < own-1e-9 {
+					t.Fatalf("corner %v of cell %d is closer to site %d", v, i, j)
+				}
+			}
+		}
+	}
+	if math.Abs(total-120*120) > 1e-6 {
+		t.Errorf("cells cover %g, want the bounds area %g", total, 120.0*120)
+	}
+
+	for k, v := range d.Vertices {
+		tri := d.Delaunay.Triangles[k]
+		r := v.DistanceTo(sites[tri[0]])
+		if math.Abs(v.DistanceTo(sites[tri[1]])-r) > 1e-9 || math.Abs(v.DistanceTo(sites[tri[2]])-r) > 1e-9 {
+			t.Fatalf("vertex %d is not equidistant from the sites of %v", k, tri)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	sites := randomSites(100)
+	d := New(sites)
+	r := rand.New(rand.NewSource(2))
+	for q := 0; q < 500; q++ {
+		p := vector2.Vector2{X: r.Float64()*120 - 10, Y: r.Float64()*120 - 10}
+		want := 0
+		for i, s := range sites {
+			if s.DistanceSquaredTo(p) < sites[want].DistanceSquaredTo(p) {
+				want = i
+			}
+		}
+		if got := d.Nearest(p, q%len(sites)); got != want {
+			t.Fatalf("Nearest(%v) = %d, want %d", p, got, want)
+		}
+	}
+	if got := New(nil).Nearest(vector2.Vector2{}, 0); got != -1 {
+		t.Errorf("Nearest without sites = %d, want -1", got)
+	}
+}
+
+func TestCollinearCells(t *testing.T) {
+	sites := []vector2.Vector2{{X: 4, Y: 0}, {X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 0}}
+	d := New(sites)
+	bounds := []vector2.Vector2{{X: -1, Y: -1}, {X: 5, Y: -1}, {X: 5, Y: 1}, {X: -1, Y: 1}}
+
+	want := []float64{2 * 2, 2 * 2, 2 * 2, 0}
+	for i, cell := range d.Cells(bounds) {
+		if got := signedArea(cell); math.Abs(got-want[i]) > 1e-9 {
+			t.Errorf("cell %d has area %g, want %g", i, got, want[i])
+		}
+	}
+}
@@ -21,17 +21,30 @@ func NewArc(centerX, centerY, radius, angleStart, angleEnd float64) Arc {
 	}
 }
 
+// ArcFromPoints fits an arc through points, running from the first point to
+// the last. The middle point picks the direction of the sweep, and AngleEnd
+// is unwrapped to match, so an arc across the ±π seam keeps its side.
 func ArcFromPoints(points []vector2.Vector2) *Arc {
 	origin, radius := fitArc(points)
 	a1 := points[0]
 	a2 := points[len(points)-1]
+	mid := points[len(points)/2]
+	angleStart := origin.AngleToPoint(a1)
+	sweep := math.Mod(origin.AngleToPoint(a2)-angleStart, 2*math.Pi)
+	if sweep < 0 {
+		sweep += 2 * math.Pi
+	}
+	// The middle point lies right of the chord when the arc turns clockwise.
+	if a2.Sub(a1).Cross(mid.Sub(a1)) > 0 {
+		sweep -= 2 * math.Pi
+	}
 	return &Arc{
 		Circle: Circle{
 			Center: origin,
 			Radius: radius,
 		},
-		AngleStart: origin.AngleToPoint(a1),
-		AngleEnd:   origin.AngleToPoint(a2),
+		AngleStart: angleStart,
+		AngleEnd:   angleStart + sweep,
 	}
 }
 
@@ -45,8 +58,15 @@ func (a Arc) Scale(factor float64) Shape {
 	return a
 }
 
+// GetBoundingBox returns the exact bounds of the arc's curve, including any
+// axis extremes crossed by the sweep.
 func (a Arc) GetBoundingBox() rect2.Rect2 {
-	return rect2.Rect2{}
+	rect := rect2.Rect2{Position: a.StartPoint()}.Expand(a.EndPoint())
+	lo, hi := math.Min(a.AngleStart, a.AngleEnd), math.Max(a.AngleStart, a.AngleEnd)
+	for k := math.Ceil(lo / (math.Pi / 2)); k*math.Pi/2 <= hi; k++ {
+		rect = rect.Expand(a.PointAt(k * math.Pi / 2))
+	}
+	return rect
 }
 
 func (a Arc) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
@@ -76,8 +96,27 @@ func (a Arc) DrawFilled(dc *gg.Context, color [4]float64) {
 	dc.Pop()
 }
 
-func (a Arc) SignedDistance(x, y int) float64 {
-	return 0.0
+// SignedDistance returns the distance to the arc's curve. Like a line, an
+// arc has no inside, so the result is never negative.
+func (a Arc) SignedDistance(p vector2.Vector2) float64 {
+	if a.withinSweep(p) {
+		return math.Abs(p.DistanceTo(a.Circle.Center) - a.Circle.Radius)
+	}
+	return math.Min(p.DistanceTo(a.StartPoint()), p.DistanceTo(a.EndPoint()))
+}
+
+// SampleDistance returns the distance to the arc's curve with its nearest
+// point. On the curve the gradient points away from the center.
+func (a Arc) SampleDistance(p vector2.Vector2) DistanceSample {
+	direction := p.Sub(a.Circle.Center).Normalized()
+	if a.withinSweep(p) && (direction.X != 0 || direction.Y != 0) {
+		return newDistanceSample(p, a.Circle.Center.Add(direction.Mulf(a.Circle.Radius)), false, direction)
+	}
+	closest, angle := a.StartPoint(), a.AngleStart
+	if p.DistanceTo(a.EndPoint()) < p.DistanceTo(closest) {
+		closest, angle = a.EndPoint(), a.AngleEnd
+	}
+	return newDistanceSample(p, closest, false, vector2.Vector2{X: math.Cos(angle), Y: math.Sin(angle)})
 }
 
 func (a Arc) GetArcBetweenPoints(start, end vector2.Vector2) *Arc {
@@ -94,6 +133,34 @@ func (a *Arc) AngleToPoint(point vector2.Vector2) float64 {
 	return a.Circle.Center.AngleToPoint(point)
 }
 
+// Sweep returns the signed angle travelled from AngleStart to AngleEnd.
+func (a Arc) Sweep() float64 {
+	return a.AngleEnd - a.AngleStart
+}
+
+// PointAt returns the point on the arc's circle at the given angle.
+func (a Arc) PointAt(angle float64) vector2.Vector2 {
+	return vector2.Vector2{
+		X: a.Circle.Center.X + a.Circle.Radius*math.Cos(angle),
+		Y: a.Circle.Center.Y + a.Circle.Radius*math.Sin(angle),
+	}
+}
+
+func (a Arc) StartPoint() vector2.Vector2 {
+	return a.PointAt(a.AngleStart)
+}
+
+func (a Arc) EndPoint() vector2.Vector2 {
+	return a.PointAt(a.AngleEnd)
+}
+
+// Direction returns the travel direction of the arc as reported by
+// ArcDirection: 1 for clockwise, -1 for counter-clockwise.
+func (a Arc) Direction() int {
+	mid := a.PointAt(a.AngleStart + a.Sweep()/2)
+	return ArcDirection(a.StartPoint(), mid, a.Circle.Center)
+}
+
 // ArcDirection determines the direction of the arc: 1 for clockwise, -1 for counter-clockwise.
 // It takes lastPosition, currentPosition and arcOrigin, all as Vector3.
 func ArcDirection(lastPosition, position, arcOrigin vector2.Vector2) int {
@@ -123,15 +190,15 @@ func ArcDirection(lastPosition, position, arcOrigin vector2.Vector2) int {
 	}
 }
 
+// Discretize samples the arc from AngleStart to AngleEnd along its signed
+// Sweep, with an odd number of points at most maxInterval apart along the arc
+// and at least minSteps of them.
 func (a *Arc) Discretize(maxInterval float64, minSteps int) []vector2.Vector2 {
-	// Calculate the total angle span of the arc
-	totalAngle := a.AngleEnd - a.AngleStart
-	if totalAngle < 0 {
-		totalAngle += 2 * math.Pi
-	}
+	// Calculate the signed angle span of the arc; clockwise arcs step backwards
+	totalAngle := a.Sweep()
 
 	// Calculate the total length of the arc
-	arcLength := a.Circle.Radius * totalAngle
+	arcLength := a.Circle.Radius * math.Abs(totalAngle)
 
 	// Calculate the minimum number of steps required based on maxInterval
 	minStepsBasedOnInterval := int(math.Ceil(arcLength / maxInterval))
@@ -0,0 +1,58 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func TestPolygonizeFollowsSweep(t *testing.T) {
+	r := 10 / math.Sqrt2
+	tests := []struct {
+		name string
+		arc  Arc
+		mid  vector2.Vector2
+	}{
+		{"counter-clockwise", NewArc(0, 0, 10, 0, math.Pi/2), vector2.Vector2{X: r, Y: r}},
+		{"clockwise", NewArc(0, 0, 10, 0, -math.Pi/2), vector2.Vector2{X: r, Y: -r}},
+		{"clockwise across zero", NewArc(0, 0, 10, math.Pi/4, -math.Pi/4), vector2.Vector2{X: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points := Polygonize(tt.arc, 0.01)
+			if !points[0].IsEqualApprox(tt.arc.StartPoint()) || !points[len(points)-1].IsEqualApprox(tt.arc.EndPoint()) {
+				t.Errorf("ends = %v, %v, want %v, %v", points[0], points[len(points)-1], tt.arc.StartPoint(), tt.arc.EndPoint())
+			}
+			if mid := points[len(points)/2]; mid.DistanceTo(tt.mid) > 1e-9 {
+				t.Errorf("midpoint = %v, want %v", mid, tt.mid)
+			}
+		})
+	}
+}
+
+func TestArcFromPointsAcrossSeam(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		start, end float64
+	}{
+		{"counter-clockwise", 170 * math.Pi / 180, 190 * math.Pi / 180},
+		{"clockwise", 190 * math.Pi / 180, 170 * math.Pi / 180},
+		{"wide counter-clockwise", 100 * math.Pi / 180, 260 * math.Pi / 180},
+		{"clockwise across zero", math.Pi / 6, -math.Pi / 6},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			want := NewArc(0, 0, 10, tt.start, tt.end)
+			arc := ArcFromPoints(want.Discretize(0.01, 3))
+			if math.Abs(arc.Sweep()-want.Sweep()) > 1e-6 {
+				t.Errorf("sweep = %g, want %g", arc.Sweep(), want.Sweep())
+			}
+			if !arc.StartPoint().IsEqualApprox(want.StartPoint()) || !arc.EndPoint().IsEqualApprox(want.EndPoint()) {
+				t.Errorf("ends = %v, %v, want %v, %v", arc.StartPoint(), arc.EndPoint(), want.StartPoint(), want.EndPoint())
+			}
+			if mid := arc.PointAt(arc.AngleStart + arc.Sweep()/2); mid.DistanceTo(want.PointAt(tt.start+want.Sweep()/2)) > 1e-6 {
+				t.Errorf("midpoint = %v, want it on the fitted side", mid)
+			}
+		})
+	}
+}
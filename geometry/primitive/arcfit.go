@@ -0,0 +1,193 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/geometry2d"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// minArcPoints is the smallest run of vertices that is replaced by an arc.
+const minArcPoints = 4
+
+// maxArcRadiusFactor rejects arcs whose radius exceeds this multiple of their
+// chord; such runs are effectively straight and are kept as lines.
+const maxArcRadiusFactor = 1000.0
+
+// FitArcs replaces runs of the polygon's vertices that lie within tolerance of
+// a circle by arcs, and merges collinear runs into single lines. The result
+// walks the closed outline as a sequence of Line and Arc shapes; a polygon
+// that follows one circle all the way round becomes a single full-turn arc.
+func (p Polygon) FitArcs(tolerance float64) []Shape {
+	if len(p) == 0 {
+		return nil
+	}
+	closed := append(append(Polygon{}, p...), p[0])
+	return FitArcs(closed, tolerance)
+}
+
+// FitArcs walks a polyline and returns a mixed sequence of Line and Arc
+// shapes that stays within tolerance of every vertex and edge midpoint.
+// An arc that follows another segment is first fitted tangent to it, and
+// only falls back to a free fit when the tangent circle is out of tolerance.
+func FitArcs(points []vector2.Vector2, tolerance float64) []Shape {
+	var shapes []Shape
+	var tangent vector2.Vector2
+	hasTangent := false
+
+	for i := 0; i < len(points)-1; {
+		lineEnd := i + 1
+		for lineEnd+1 < len(points) && fitsLine(points[i:lineEnd+2], tolerance) {
+			lineEnd++
+		}
+
+		arcEnd := -1
+		var arc Arc
+		for j := i + minArcPoints - 1; j < len(points); j++ {
+			candidate, ok := fitRun(points[i:j+1], tangent, hasTangent, tolerance)
+			if !ok {
+				break
+			}
+			arcEnd = j
+			arc = candidate
+		}
+
+		if arcEnd > lineEnd {
+			shapes = append(shapes, arc)
+			tangent = arcTangent(arc, arc.AngleEnd)
+			i = arcEnd
+		} else {
+			// Hand the last vertex of a long line to a following arc, since a
+			// line within tolerance tends to swallow the start of a curve.
+			if lineEnd-1 > i && lineEnd+minArcPoints-2 < len(points) {
+				dir := points[lineEnd-1].Sub(points[i]).Normalized()
+				if _, ok := fitRun(points[lineEnd-1:lineEnd+minArcPoints-1], dir, true, tolerance); ok {
+					lineEnd--
+				}
+			}
+			shapes = append(shapes, Line{Start: points[i], End: points[lineEnd]})
+			tangent = points[lineEnd].Sub(points[i]).Normalized()
+			i = lineEnd
+		}
+		hasTangent = true
+	}
+	return shapes
+}
+
+// fitRun fits an arc to the run, tangent to the incoming direction when
+// possible.
+func fitRun(run []vector2.Vector2, tangent vector2.Vector2, hasTangent bool, tolerance float64) (Arc, bool) {
+	if hasTangent {
+		if center, radius, ok := tangentCircle(run[0], tangent, run[len(run)-1]); ok {
+			if arc, ok := arcThrough(run, center, radius, tolerance); ok {
+				return arc, true
+			}
+		}
+	}
+	if center, radius := fitArc(run); radius > 0 {
+		if arc, ok := arcThrough(run, center, radius, tolerance); ok {
+			return arc, true
+		}
+	}
+	// fitArc breaks down past a half turn, where every vertex is far from the
+	// middle of the chord, so fall back to a circle through three vertices
+	// spread along the run.
+	n := len(run)
+	center, radius, ok := circleThrough(run[0], run[n/3], run[2*n/3])
+	if !ok {
+		return Arc{}, false
+	}
+	return arcThrough(run, center, radius, tolerance)
+}
+
+// circleThrough returns the circle through three points, or false when they
+// are collinear.
+func circleThrough(a, b, c vector2.Vector2) (vector2.Vector2, float64, bool) {
+	ab, ac := b.Sub(a), c.Sub(a)
+	d := 2 * ab.Cross(ac)
+	if math.Abs(d) < 1e-12 {
+		return vector2.Vector2{}, 0, false
+	}
+	ab2, ac2 := ab.Dot(ab), ac.Dot(ac)
+	offset := vector2.Vector2{X: (ac.Y*ab2 - ab.Y*ac2) / d, Y: (ab.X*ac2 - ac.X*ab2) / d}
+	return a.Add(offset), offset.Length(), true
+}
+
+// arcThrough builds the arc on the given circle that follows the run, or
+// reports false when the run strays from the circle, turns back on itself or
+// is too flat to be worth an arc.
+func arcThrough(run []vector2.Vector2, center vector2.Vector2, radius, tolerance float64) (Arc, bool) {
+	chord := run[0].DistanceTo(run[len(run)-1])
+	if radius > maxArcRadiusFactor*math.Max(chord, tolerance) {
+		return Arc{}, false
+	}
+
+	sweep := 0.0
+	direction := 0.0
+	for k := 0; k < len(run); k++ {
+		if math.Abs(run[k].DistanceTo(center)-radius) > tolerance {
+			return Arc{}, false
+		}
+		if k == 0 {
+			continue
+		}
+		mid := run[k-1].Add(run[k]).Mulf(0.5)
+		if math.Abs(mid.DistanceTo(center)-radius) > tolerance {
+			return Arc{}, false
+		}
+		step := angleBetween(run[k-1].Sub(center), run[k].Sub(center))
+		if direction == 0 {
+			direction = math.Copysign(1, step)
+		} else if step*direction <= 0 {
+			return Arc{}, false
+		}
+		sweep += step
+	}
+	// A closed run makes a full turn; anything more overlaps itself.
+	if math.Abs(sweep) > 2*math.Pi+1e-9 {
+		return Arc{}, false
+	}
+
+	start := center.AngleToPoint(run[0])
+	return Arc{
+		Circle:     Circle{Center: center, Radius: radius},
+		AngleStart: start,
+		AngleEnd:   start + sweep,
+	}, true
+}
+
+// tangentCircle returns the circle through start and end that is tangent to
+// the given direction at start.
+func tangentCircle(start, tangent, end vector2.Vector2) (vector2.Vector2, float64, bool) {
+	normal := vector2.Vector2{X: -tangent.Y, Y: tangent.X}
+	d := end.Sub(start)
+	denom := 2 * normal.Dot(d)
+	if math.Abs(denom) < 1e-12 {
+		return vector2.Vector2{}, 0, false
+	}
+	r := d.Dot(d) / denom
+	return start.Add(normal.Mulf(r)), math.Abs(r), true
+}
+
+// arcTangent returns the unit direction of travel of the arc at angle.
+func arcTangent(a Arc, angle float64) vector2.Vector2 {
+	t := vector2.Vector2{X: -math.Sin(angle), Y: math.Cos(angle)}
+	if a.Sweep() < 0 {
+		return t.Mulf(-1)
+	}
+	return t
+}
+
+func angleBetween(from, to vector2.Vector2) float64 {
+	return math.Atan2(from.Cross(to), from.Dot(to))
+}
+
+func fitsLine(run []vector2.Vector2, tolerance float64) bool {
+	segment := [2]vector2.Vector2{run[0], run[len(run)-1]}
+	for _, p := range run[1 : len(run)-1] {
+		if geometry2d.GetDistanceToSegment(p, segment) > tolerance {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// withinTolerance checks that every vertex of points lies within tolerance
+// of one of the shapes.
+func withinTolerance(t *testing.T, shapes []Shape, points []vector2.Vector2, tolerance float64) {
+	t.Helper()
+	for _, p := range points {
+		best := math.Inf(1)
+		for _, s := range shapes {
+			best = math.Min(best, s.SampleDistance(p).Closest.DistanceTo(p))
+		}
+		if best > tolerance+1e-9 {
+			t.Errorf("vertex %v is %g from the fitted shapes", p, best)
+		}
+	}
+}
+
+func TestFitArcsCircle(t *testing.T) {
+	circle := NewCircle(3, 4, 10)
+	ring := Polygonize(circle, 0.01)
+	shapes := ring.FitArcs(0.02)
+	if len(shapes) != 1 {
+		t.Fatalf("got %d shapes %v, want one arc", len(shapes), shapes)
+	}
+	arc, ok := shapes[0].(Arc)
+	if !ok {
+		t.Fatalf("got %T, want Arc", shapes[0])
+	}
+	if arc.Circle.Center.DistanceTo(circle.Center) > 0.02 || math.Abs(arc.Circle.Radius-circle.Radius) > 0.02 {
+		t.Errorf("fitted circle %v, want %v", arc.Circle, circle)
+	}
+	if math.Abs(math.Abs(arc.Sweep())-2*math.Pi) > 1e-9 {
+		t.Errorf("sweep = %g, want a full turn", arc.Sweep())
+	}
+	withinTolerance(t, shapes, ring, 0.02)
+
+	// An open run along part of a circle, clockwise, refits to one arc too.
+	want := NewArc(0, 0, 5, 2, -2)
+	points := want.Discretize(0.5, 3)
+	shapes = FitArcs(points, 0.01)
+	if len(shapes) != 1 {
+		t.Fatalf("got %d shapes %v, want one arc", len(shapes), shapes)
+	}
+	if arc, ok := shapes[0].(Arc); !ok || math.Abs(arc.Sweep()-want.Sweep()) > 1e-6 {
+		t.Errorf("got %+v, want %+v", shapes[0], want)
+	}
+	withinTolerance(t, shapes, points, 0.01)
+}
+
+func TestFitArcsStraightRuns(t *testing.T) {
+	points := []vector2.Vector2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0.001}, {X: 3, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 1}, {X: 4, Y: 2}, {X: 4, Y: 3}}
+	shapes := FitArcs(points, 0.01)
+	want := []Line{
+		{Start: vector2.Vector2{X: 0, Y: 0}, End: vector2.Vector2{X: 4, Y: 0}},
+		{Start: vector2.Vector2{X: 4, Y: 0}, End: vector2.Vector2{X: 4, Y: 3}},
+	}
+	if len(shapes) != len(want) {
+		t.Fatalf("got %v, want %v", shapes, want)
+	}
+	for i := range want {
+		if shapes[i] != want[i] {
+			t.Errorf("shape %d = %v, want %v", i, shapes[i], want[i])
+		}
+	}
+
+	if got := (Polygon{}).FitArcs(0.01); got != nil {
+		t.Errorf("got %v for an empty polygon, want nil", got)
+	}
+	if got := FitArcs(points[:1], 0.01); got != nil {
+		t.Errorf("got %v for a single point, want nil", got)
+	}
+}
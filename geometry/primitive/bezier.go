@@ -0,0 +1,334 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
+	"github.com/fogleman/gg"
+)
+
+// QuadraticBezier is a Bezier curve with a single control point, running
+// from Start at t = 0 to End at t = 1.
+type QuadraticBezier struct {
+	Start, Control, End vector2.Vector2
+}
+
+// CubicBezier is a Bezier curve with two control points, running from Start
+// at t = 0 to End at t = 1.
+type CubicBezier struct {
+	Start, Control1, Control2, End vector2.Vector2
+}
+
+func NewQuadraticBezier(startX, startY, controlX, controlY, endX, endY float64) QuadraticBezier {
+	return QuadraticBezier{
+		Start:   vector2.Vector2{X: startX, Y: startY},
+		Control: vector2.Vector2{X: controlX, Y: controlY},
+		End:     vector2.Vector2{X: endX, Y: endY},
+	}
+}
+
+func NewCubicBezier(startX, startY, control1X, control1Y, control2X, control2Y, endX, endY float64) CubicBezier {
+	return CubicBezier{
+		Start:    vector2.Vector2{X: startX, Y: startY},
+		Control1: vector2.Vector2{X: control1X, Y: control1Y},
+		Control2: vector2.Vector2{X: control2X, Y: control2Y},
+		End:      vector2.Vector2{X: endX, Y: endY},
+	}
+}
+
+// PointAt returns the point of the curve at t.
+func (b QuadraticBezier) PointAt(t float64) vector2.Vector2 {
+	s := 1 - t
+	return b.Start.Mulf(s * s).Add(b.Control.Mulf(2 * s * t)).Add(b.End.Mulf(t * t))
+}
+
+// Derivative returns the tangent of the curve at t.
+func (b QuadraticBezier) Derivative(t float64) vector2.Vector2 {
+	return b.Control.Sub(b.Start).Mulf(2 * (1 - t)).Add(b.End.Sub(b.Control).Mulf(2 * t))
+}
+
+// SecondDerivative returns the second derivative of the curve, which is the
+// same everywhere.
+func (b QuadraticBezier) SecondDerivative(t float64) vector2.Vector2 {
+	return b.Start.Sub(b.Control.Mulf(2)).Add(b.End).Mulf(2)
+}
+
+// Split divides the curve at t into the parts before and after it.
+func (b QuadraticBezier) Split(t float64) (QuadraticBezier, QuadraticBezier) {
+	c0 := lerp(b.Start, b.Control, t)
+	c1 := lerp(b.Control, b.End, t)
+	mid := lerp(c0, c1, t)
+	return QuadraticBezier{b.Start, c0, mid}, QuadraticBezier{mid, c1, b.End}
+}
+
+// Cubic returns the same curve as a CubicBezier.
+func (b QuadraticBezier) Cubic() CubicBezier {
+	return CubicBezier{
+		Start:    b.Start,
+		Control1: lerp(b.Start, b.Control, 2.0/3),
+		Control2: lerp(b.End, b.Control, 2.0/3),
+		End:      b.End,
+	}
+}
+
+// PointAt returns the point of the curve at t.
+func (b CubicBezier) PointAt(t float64) vector2.Vector2 {
+	s := 1 - t
+	return b.Start.Mulf(s * s * s).
+		Add(b.Control1.Mulf(3 * s * s * t)).
+		Add(b.Control2.Mulf(3 * s * t * t)).
+		Add(b.End.Mulf(t * t * t))
+}
+
+// Derivative returns the tangent of the curve at t.
+func (b CubicBezier) Derivative(t float64) vector2.Vector2 {
+	s := 1 - t
+	return b.Control1.Sub(b.Start).Mulf(3 * s * s).
+		Add(b.Control2.Sub(b.Control1).Mulf(6 * s * t)).
+		Add(b.End.Sub(b.Control2).Mulf(3 * t * t))
+}
+
+// SecondDerivative returns the second derivative of the curve at t.
+func (b CubicBezier) SecondDerivative(t float64) vector2.Vector2 {
+	first := b.Start.Sub(b.Control1.Mulf(2)).Add(b.Control2)
+	second := b.Control1.Sub(b.Control2.Mulf(2)).Add(b.End)
+	return first.Mulf(6 * (1 - t)).Add(second.Mulf(6 * t))
+}
+
+// Split divides the curve at t into the parts before and after it.
+func (b CubicBezier) Split(t float64) (CubicBezier, CubicBezier) {
+	c01 := lerp(b.Start, b.Control1, t)
+	c12 := lerp(b.Control1, b.Control2, t)
+	c23 := lerp(b.Control2, b.End, t)
+	c012 := lerp(c01, c12, t)
+	c123 := lerp(c12, c23, t)
+	mid := lerp(c012, c123, t)
+	return CubicBezier{b.Start, c01, c012, mid}, CubicBezier{mid, c123, c23, b.End}
+}
+
+func (b QuadraticBezier) Translate(offsetX, offsetY float64) Shape {
+	return b.Transform(algebra.Translation(offsetX, offsetY))
+}
+
+func (b CubicBezier) Translate(offsetX, offsetY float64) Shape {
+	return b.Transform(algebra.Translation(offsetX, offsetY))
+}
+
+func (b QuadraticBezier) Scale(factor float64) Shape {
+	return b.Transform(algebra.Scaling(factor, factor))
+}
+
+func (b CubicBezier) Scale(factor float64) Shape {
+	return b.Transform(algebra.Scaling(factor, factor))
+}
+
+// Transform maps the control points, which maps the curve exactly.
+func (b QuadraticBezier) Transform(m algebra.Affine) Shape {
+	return QuadraticBezier{m.Apply(b.Start), m.Apply(b.Control), m.Apply(b.End)}
+}
+
+// Transform maps the control points, which maps the curve exactly.
+func (b CubicBezier) Transform(m algebra.Affine) Shape {
+	return CubicBezier{m.Apply(b.Start), m.Apply(b.Control1), m.Apply(b.Control2), m.Apply(b.End)}
+}
+
+// GetBoundingBox returns the exact bounds of the curve, found from the end
+// points and the points where the tangent is horizontal or vertical.
+func (b QuadraticBezier) GetBoundingBox() rect2.Rect2 {
+	rect := rect2.Rect2{Position: b.Start}.Expand(b.End)
+	// The derivative is linear in t along each axis.
+	for _, axis := range [][3]float64{{b.Start.X, b.Control.X, b.End.X}, {b.Start.Y, b.Control.Y, b.End.Y}} {
+		for _, t := range unitRoots(0, axis[2]-2*axis[1]+axis[0], axis[1]-axis[0]) {
+			rect = rect.Expand(b.PointAt(t))
+		}
+	}
+	return rect
+}
+
+// GetBoundingBox returns the exact bounds of the curve, found from the end
+// points and the points where the tangent is horizontal or vertical.
+func (b CubicBezier) GetBoundingBox() rect2.Rect2 {
+	rect := rect2.Rect2{Position: b.Start}.Expand(b.End)
+	// A third of the derivative is a quadratic in t along each axis.
+	for _, axis := range [][4]float64{
+		{b.Start.X, b.Control1.X, b.Control2.X, b.End.X},
+		{b.Start.Y, b.Control1.Y, b.Control2.Y, b.End.Y},
+	} {
+		a := -axis[0] + 3*axis[1] - 3*axis[2] + axis[3]
+		c := 2 * (axis[0] - 2*axis[1] + axis[2])
+		for _, t := range unitRoots(a, c, axis[1]-axis[0]) {
+			rect = rect.Expand(b.PointAt(t))
+		}
+	}
+	return rect
+}
+
+// unitRoots returns the roots of a t² + b t + c strictly between 0 and 1.
+func unitRoots(a, b, c float64) []float64 {
+	var roots []float64
+	if math.Abs(a) <= 1e-12*(math.Abs(b)+math.Abs(c)) {
+		if b != 0 {
+			roots = append(roots, -c/b)
+		}
+	} else if disc := b*b - 4*a*c; disc >= 0 {
+		// Avoid cancellation by computing the larger root first.
+		q := -(b + math.Copysign(math.Sqrt(disc), b)) / 2
+		roots = append(roots, q/a)
+		if q != 0 {
+			roots = append(roots, c/q)
+		}
+	}
+	inside := roots[:0]
+	for _, t := range roots {
+		if t > 0 && t < 1 {
+			inside = append(inside, t)
+		}
+	}
+	return inside
+}
+
+// Flatten returns the curve as an open polyline whose chords stay within
+// tolerance of it, with more points where it bends more. A tolerance of zero
+// selects DefaultTolerance.
+func (b QuadraticBezier) Flatten(tolerance float64) Polygon {
+	return flattenPieces(b.pieces(), tolerance)
+}
+
+// Flatten returns the curve as an open polyline whose chords stay within
+// tolerance of it, with more points where it bends more. A tolerance of zero
+// selects DefaultTolerance.
+func (b CubicBezier) Flatten(tolerance float64) Polygon {
+	return flattenPieces(b.pieces(), tolerance)
+}
+
+func (b QuadraticBezier) pieces() [][]hpoint {
+	return [][]hpoint{{homogeneous(b.Start, 1), homogeneous(b.Control, 1), homogeneous(b.End, 1)}}
+}
+
+func (b CubicBezier) pieces() [][]hpoint {
+	return [][]hpoint{{homogeneous(b.Start, 1), homogeneous(b.Control1, 1), homogeneous(b.Control2, 1), homogeneous(b.End, 1)}}
+}
+
+func (b QuadraticBezier) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	b.path(dc)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (b CubicBezier) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	b.path(dc)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (b QuadraticBezier) DrawDashed(dc *gg.Context, color [4]float64, lwidth, dashLength, dashInterval float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	b.path(dc)
+	dc.SetDash(dashLength, dashInterval)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (b CubicBezier) DrawDashed(dc *gg.Context, color [4]float64, lwidth, dashLength, dashInterval float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	b.path(dc)
+	dc.SetDash(dashLength, dashInterval)
+	dc.Stroke()
+	dc.Pop()
+}
+
+// DrawFilled fills the area between the curve and its chord.
+func (b QuadraticBezier) DrawFilled(dc *gg.Context, color [4]float64) {
+	dc.Push()
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	b.path(dc)
+	dc.Fill()
+	dc.Pop()
+}
+
+// DrawFilled fills the area between the curve and its chord.
+func (b CubicBezier) DrawFilled(dc *gg.Context, color [4]float64) {
+	dc.Push()
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	b.path(dc)
+	dc.Fill()
+	dc.Pop()
+}
+
+func (b QuadraticBezier) path(dc *gg.Context) {
+	dc.NewSubPath()
+	dc.MoveTo(b.Start.X, b.Start.Y)
+	dc.QuadraticTo(b.Control.X, b.Control.Y, b.End.X, b.End.Y)
+}
+
+func (b CubicBezier) path(dc *gg.Context) {
+	dc.NewSubPath()
+	dc.MoveTo(b.Start.X, b.Start.Y)
+	dc.CubicTo(b.Control1.X, b.Control1.Y, b.Control2.X, b.Control2.Y, b.End.X, b.End.Y)
+}
+
+// SignedDistance returns the distance to the curve. Like a line, a curve
+// has no inside, so the result is never negative.
+func (b QuadraticBezier) SignedDistance(p vector2.Vector2) float64 {
+	return p.DistanceTo(b.closest(p).point)
+}
+
+// SampleDistance returns the distance to the curve with its nearest point.
+// On the curve the gradient is the normal to the left of the direction of
+// travel.
+func (b QuadraticBezier) SampleDistance(p vector2.Vector2) DistanceSample {
+	return curveDistanceSample(p, b.closest(p))
+}
+
+// SignedDistance returns the distance to the curve. Like a line, a curve
+// has no inside, so the result is never negative.
+func (b CubicBezier) SignedDistance(p vector2.Vector2) float64 {
+	return p.DistanceTo(b.closest(p).point)
+}
+
+// SampleDistance returns the distance to the curve with its nearest point.
+// On the curve the gradient is the normal to the left of the direction of
+// travel.
+func (b CubicBezier) SampleDistance(p vector2.Vector2) DistanceSample {
+	return curveDistanceSample(p, b.closest(p))
+}
+
+func (b QuadraticBezier) closest(p vector2.Vector2) curveSample {
+	eval := func(t float64) curveSample {
+		return curveSample{b.PointAt(t), b.Derivative(t), b.SecondDerivative(t)}
+	}
+	return eval(closestOnCurve(p, 0, 1, closestSamples, eval))
+}
+
+func (b CubicBezier) closest(p vector2.Vector2) curveSample {
+	eval := func(t float64) curveSample {
+		return curveSample{b.PointAt(t), b.Derivative(t), b.SecondDerivative(t)}
+	}
+	return eval(closestOnCurve(p, 0, 1, closestSamples, eval))
+}
+
+// Contains tests the point against the area between the curve and its
+// chord, the area DrawFilled fills.
+func (b QuadraticBezier) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	outline := b.Flatten(outlineTolerance(b.GetBoundingBox(), opts))
+	return curveLocate(p, b.SignedDistance(p), outline, opts).contained(opts)
+}
+
+// Contains tests the point against the area between the curve and its
+// chord, the area DrawFilled fills.
+func (b CubicBezier) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	outline := b.Flatten(outlineTolerance(b.GetBoundingBox(), opts))
+	return curveLocate(p, b.SignedDistance(p), outline, opts).contained(opts)
+}
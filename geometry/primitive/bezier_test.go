@@ -0,0 +1,129 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// polylineDistance returns the distance from p to an open polyline.
+func polylineDistance(line Polygon, p vector2.Vector2) float64 {
+	best := math.Inf(1)
+	for i := 0; i+1 < len(line); i++ {
+		a, b := line[i], line[i+1]
+		ab := b.Sub(a)
+		t := 0.0
+		if l := ab.Dot(ab); l > 0 {
+			t = math.Max(0, math.Min(1, p.Sub(a).Dot(ab)/l))
+		}
+		best = math.Min(best, a.Add(ab.Mulf(t)).DistanceTo(p))
+	}
+	return best
+}
+
+// checkFlatten tests that a flattened curve starts and ends with it and that
+// every sample of the curve lies within tolerance of the polyline.
+func checkFlatten(t *testing.T, name string, pointAt func(float64) vector2.Vector2, lo, hi, tolerance float64, line Polygon) {
+	t.Helper()
+	if len(line) < 2 {
+		t.Fatalf("%s: got %d points", name, len(line))
+	}
+	if !line[0].IsEqualApprox(pointAt(lo)) || !line[len(line)-1].IsEqualApprox(pointAt(hi)) {
+		t.Errorf("%s: polyline runs from %v to %v, want %v to %v", name, line[0], line[len(line)-1], pointAt(lo), pointAt(hi))
+	}
+	for k := 0; k <= 1000; k++ {
+		p := pointAt(lo + (hi-lo)*float64(k)/1000)
+		if d := polylineDistance(line, p); d > tolerance*(1+1e-9) {
+			t.Fatalf("%s: curve point %v is %g from the polyline, want at most %g", name, p, d, tolerance)
+		}
+	}
+}
+
+func TestCubicBezierEvaluation(t *testing.T) {
+	b := NewCubicBezier(0, 0, 1, 3, 4, 3, 5, 0)
+	bernstein := func(t float64) vector2.Vector2 {
+		s := 1 - t
+		return b.Start.Mulf(s * s * s).Add(b.Control1.Mulf(3 * s * s * t)).Add(b.Control2.Mulf(3 * s * t * t)).Add(b.End.Mulf(t * t * t))
+	}
+	const h = 1e-6
+	for _, u := range []float64{0, 0.2, 0.5, 0.9, 1} {
+		if got, want := b.PointAt(u), bernstein(u); got.DistanceTo(want) > 1e-12 {
+			t.Errorf("PointAt(%g) = %v, want %v", u, got, want)
+		}
+		want := bernstein(u + h).Sub(bernstein(u - h)).Divf(2 * h)
+		if got := b.Derivative(u); got.DistanceTo(want) > 1e-6 {
+			t.Errorf("Derivative(%g) = %v, want %v", u, got, want)
+		}
+	}
+
+	first, second := b.Split(0.3)
+	for _, u := range []float64{0, 0.5, 1} {
+		if got, want := first.PointAt(u), b.PointAt(0.3*u); got.DistanceTo(want) > 1e-12 {
+			t.Errorf("first part at %g = %v, want %v", u, got, want)
+		}
+		if got, want := second.PointAt(u), b.PointAt(0.3+0.7*u); got.DistanceTo(want) > 1e-12 {
+			t.Errorf("second part at %g = %v, want %v", u, got, want)
+		}
+	}
+
+	q := NewQuadraticBezier(0, 0, 2, 4, 4, 0)
+	c := q.Cubic()
+	for _, u := range []float64{0.1, 0.5, 0.7} {
+		if got, want := c.PointAt(u), q.PointAt(u); got.DistanceTo(want) > 1e-12 {
+			t.Errorf("elevated curve at %g = %v, want %v", u, got, want)
+		}
+	}
+}
+
+func TestBezierBoundingBox(t *testing.T) {
+	// The peak of the arch lies at t = 1/2, between the end points, at
+	// y = 3·3/4 = 2.25.
+	b := NewCubicBezier(0, 0, 1, 3, 4, 3, 5, 0)
+	box := b.GetBoundingBox()
+	want := [4]float64{0, 0, 5, 2.25}
+	got := [4]float64{box.Position.X, box.Position.Y, box.Position.X + box.Size.X, box.Position.Y + box.Size.Y}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Fatalf("got bounds %v, want %v", got, want)
+		}
+	}
+
+	q := NewQuadraticBezier(0, 0, 4, 4, 0, 2)
+	qbox := q.GetBoundingBox()
+	for k := 0; k <= 100; k++ {
+		p := q.PointAt(float64(k) / 100)
+		if p.X < qbox.Position.X-1e-12 || p.X > qbox.Position.X+qbox.Size.X+1e-12 ||
+			p.Y < qbox.Position.Y-1e-12 || p.Y > qbox.Position.Y+qbox.Size.Y+1e-12 {
+			t.Fatalf("curve point %v lies outside %v", p, qbox)
+		}
+	}
+}
+
+func TestBezierFlatten(t *testing.T) {
+	c := NewCubicBezier(0, 0, 10, 30, 40, -30, 50, 0)
+	q := NewQuadraticBezier(0, 0, 25, 50, 50, 0)
+	for _, tolerance := range []float64{1, 0.1, 0.001} {
+		cubic := c.Flatten(tolerance)
+		checkFlatten(t, "cubic", c.PointAt, 0, 1, tolerance, cubic)
+		checkFlatten(t, "quadratic", q.PointAt, 0, 1, tolerance, q.Flatten(tolerance))
+		if tolerance < 1 && len(cubic) <= len(c.Flatten(tolerance*10)) {
+			t.Errorf("tolerance %g gives no more points than %g", tolerance, tolerance*10)
+		}
+	}
+
+	straight := NewCubicBezier(0, 0, 1, 0, 2, 0, 3, 0).Flatten(0.01)
+	if len(straight) != 2 {
+		t.Errorf("straight curve flattens to %d points, want 2", len(straight))
+	}
+}
+
+func TestBezierDistance(t *testing.T) {
+	c := NewCubicBezier(0, 0, 10, 30, 40, -30, 50, 0)
+	line := c.Flatten(1e-6)
+	for _, p := range []vector2.Vector2{{X: 25, Y: 20}, {X: -5, Y: 3}, {X: 30, Y: -2}, {X: 60, Y: 10}} {
+		if got, want := c.SignedDistance(p), polylineDistance(line, p); math.Abs(got-want) > 1e-5 {
+			t.Errorf("SignedDistance(%v) = %g, want %g", p, got, want)
+		}
+	}
+}
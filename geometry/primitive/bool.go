@@ -1,7 +1,6 @@
 package primitive
 
 import (
-	"fmt"
 	"math"
 
 	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
@@ -19,10 +18,19 @@ func (bg BooleanGroup) GetBoundingBox() rect2.Rect2 {
 	return bb
 }
 
-func (bg *BooleanGroup) UnionDistance(x, y int) float64 {
+// Index returns a spatial index over the group's shapes, with ids matching
+// their positions in the group. Its UnionDistance and DifferenceDistance
+// give the same results as the group's while only evaluating nearby shapes,
+// which pays off when sampling a large group many times. The index does not
+// follow later changes to the group.
+func (bg BooleanGroup) Index() *ShapeIndex {
+	return NewShapeIndex(bg...)
+}
+
+func (bg *BooleanGroup) UnionDistance(p vector2.Vector2) float64 {
 	min := math.Inf(1) // Initialize to positive infinity
 	for _, shape := range *bg {
-		d := shape.SignedDistance(x, y)
+		d := shape.SignedDistance(p)
 		if d < min {
 			min = d
 		}
@@ -30,10 +38,10 @@ func (bg *BooleanGroup) UnionDistance(x, y int) float64 {
 	return min
 }
 
-func (bg *BooleanGroup) IntersectionDistance(x, y int) float64 {
+func (bg *BooleanGroup) IntersectionDistance(p vector2.Vector2) float64 {
 	max := math.Inf(-1) // Initialize to negative infinity
 	for _, shape := range *bg {
-		d := shape.SignedDistance(x, y)
+		d := shape.SignedDistance(p)
 		if d > max {
 			max = d
 		}
@@ -41,10 +49,10 @@ func (bg *BooleanGroup) IntersectionDistance(x, y int) float64 {
 	return max
 }
 
-func (bg *BooleanGroup) DifferenceDistance(x, y int) float64 {
+func (bg *BooleanGroup) DifferenceDistance(p vector2.Vector2) float64 {
 	min := math.Inf(1) // Initialize to positive infinity
 	for _, shape := range *bg {
-		d := shape.SignedDistance(x, y)
+		d := shape.SignedDistance(p)
 		if d < min {
 			min = d
 		}
@@ -53,66 +61,53 @@ func (bg *BooleanGroup) DifferenceDistance(x, y int) float64 {
 }
 
 // Utility functions
-func (bg *BooleanGroup) GetContour(z float64, dFunc contourmap.Function) Polygon {
-	const Scale = 10.0
-	r := (*bg)[0].GetBoundingBox()
-	for _, shape := range *bg {
-		r = r.Merge(shape.GetBoundingBox())
-	}
-	padding := 20.0
-	r = r.Grow(padding)
-	for i, _ := range *bg {
-		(*bg)[i] = (*bg)[i].Translate(-r.Position.X+padding, -r.Position.Y+padding).Scale(Scale)
-		printRect((*bg)[i].GetBoundingBox())
-	}
 
-	fmt.Println("Bounding box, size:", r.Size.X, ", ", r.Size.Y, ", position:", r.Position.X, ", ", r.Position.Y)
-	//panic("Not implemented")
-
-	cRes := [2]int{int(math.Ceil(r.Size.X * Scale)), int(math.Ceil(r.Size.Y * Scale))}
-
-	cMap := contourmap.FromFunction(cRes[0], cRes[1], dFunc).Closed()
-	contours := cMap.Contours(z)
-	println("contour size: ", len(contours))
-	simplified := simplifyContours(contours, 0.1)
-
-	boundaryTolerance := 0.001 // Larger tolerance for boundary detection
-	minBoundaryPoints := 0.2   // Discard if more than 20% of points are on the boundary
+// GetContour traces the z level set of dFunc over the group's bounds, grown
+// to leave room for the level set, by sampling dFunc on a grid with the
+// given cell size and interpolating between samples. Every closed contour is
+// kept, so holes and disjoint pieces survive; they are combined with the
+// even-odd rule and normalized.
+func (bg *BooleanGroup) GetContour(z, cellSize float64, dFunc func(p vector2.Vector2) float64) Region {
+	return traceContour(bg.GetBoundingBox(), z, cellSize, dFunc)
+}
 
-	var largestContour []vector2.Vector2
+// traceContour traces the z level set of dFunc over bounds, grown by z and
+// two cells so that the level set of a distance field bounded by them stays
+// clear of the grid's edge. Contours that run along the edge of the grid are
+// where dFunc stays below z outside the bounds, and are discarded.
+func traceContour(bounds rect2.Rect2, z, cellSize float64, dFunc func(p vector2.Vector2) float64) Region {
+	if cellSize <= 0 {
+		return Region{}
+	}
+	bounds = bounds.Grow(math.Max(z, 0) + 2*cellSize)
+	origin := bounds.Position
+	w := int(math.Ceil(bounds.Size.X/cellSize)) + 1
+	h := int(math.Ceil(bounds.Size.Y/cellSize)) + 1
+	grid := func(x, y int) float64 {
+		return dFunc(vector2.Vector2{X: origin.X + float64(x)*cellSize, Y: origin.Y + float64(y)*cellSize})
+	}
+	contours := contourmap.FromFunction(w, h, grid).Closed().Contours(z)
 
-	for _, s := range simplified {
-		boundaryPoints := 0
+	const maxEdgeRatio = 0.2 // Discard if more than 20% of points are on the edge
 
-		// Count how many points are close to the boundary
+	var rings []Polygon
+	for _, s := range simplifyContours(contours, 0.1) {
+		// The closed map pads the grid with one cell, so samples lie at
+		// 1..w and the padding at 0 and w+1.
+		edgePoints := 0
 		for _, pt := range s {
-			if pt.X < boundaryTolerance || pt.Y < boundaryTolerance ||
-				pt.X > float64(cRes[0])-boundaryTolerance || pt.Y > float64(cRes[1])-boundaryTolerance {
-				boundaryPoints++
+			if pt.X < 0.5 || pt.Y < 0.5 || pt.X > float64(w)+0.5 || pt.Y > float64(h)+0.5 {
+				edgePoints++
 			}
 		}
-
-		boundaryRatio := float64(boundaryPoints) / float64(len(s))
-
-		// Discard contours with too many boundary points
-		if boundaryRatio < minBoundaryPoints {
-			if len(s) > len(largestContour) {
-				largestContour = s // Keep the largest valid contour
-			}
-		} else {
-			println("Ignoring boundary contour with size:", len(s))
+		if float64(edgePoints)/float64(len(s)) >= maxEdgeRatio {
+			continue
 		}
+		ring := make(Polygon, len(s))
+		for i, pt := range s {
+			ring[i] = vector2.Vector2{X: origin.X + (pt.X-1)*cellSize, Y: origin.Y + (pt.Y-1)*cellSize}
+		}
+		rings = append(rings, ring)
 	}
-
-	if len(largestContour) > 0 {
-		p := Polygon(largestContour).Scale(1.0/Scale).Translate(r.Position.X, r.Position.Y).(Polygon)
-		return p
-	}
-
-	// Return an empty slice if no valid contours were found
-	return []vector2.Vector2{}
-}
-
-func printRect(rect rect2.Rect2) {
-	fmt.Println("Rect: ", rect.Position.X, ", ", rect.Position.Y, ", ", rect.Size.X, ", ", rect.Size.Y)
+	return NewRegion(EvenOdd, rings...).Normalized()
 }
@@ -0,0 +1,483 @@
+package primitive
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
+	"github.com/fogleman/gg"
+)
+
+// BSpline is a B-spline curve of any degree. It is a NURBS curve when
+// Weights is set, with one positive weight per control point; otherwise
+// every weight is one. Knots holds len(Points) + Degree + 1 non-decreasing
+// values, and the curve is defined for parameters between
+// Knots[Degree] and Knots[len(Points)].
+type BSpline struct {
+	Degree  int
+	Points  []vector2.Vector2
+	Knots   []float64
+	Weights []float64
+}
+
+// NewBSpline returns a polynomial B-spline with the given knot vector.
+func NewBSpline(degree int, points []vector2.Vector2, knots []float64) BSpline {
+	return BSpline{Degree: degree, Points: points, Knots: knots}
+}
+
+// NewUniformBSpline returns a polynomial B-spline on an open uniform knot
+// vector over [0, 1]: the end knots are repeated Degree + 1 times, so the
+// curve starts and ends at the end control points, and the interior knots
+// are evenly spaced.
+func NewUniformBSpline(degree int, points []vector2.Vector2) BSpline {
+	return BSpline{Degree: degree, Points: points, Knots: uniformKnots(degree, len(points))}
+}
+
+// NewNURBS returns a rational B-spline with the given weights and knot
+// vector.
+func NewNURBS(degree int, points []vector2.Vector2, weights, knots []float64) BSpline {
+	return BSpline{Degree: degree, Points: points, Knots: knots, Weights: weights}
+}
+
+func uniformKnots(degree, count int) []float64 {
+	if degree < 1 || count <= degree {
+		return nil
+	}
+	spans := count - degree
+	knots := make([]float64, 0, count+degree+1)
+	for i := 0; i < count+degree+1; i++ {
+		k := math.Min(math.Max(float64(i-degree), 0), float64(spans))
+		knots = append(knots, k/float64(spans))
+	}
+	return knots
+}
+
+// valid reports whether the degree, points, knots and weights fit together.
+func (s BSpline) valid() bool {
+	n := len(s.Points)
+	if s.Degree < 1 || n <= s.Degree || len(s.Knots) != n+s.Degree+1 {
+		return false
+	}
+	if s.Weights != nil && len(s.Weights) != n {
+		return false
+	}
+	for i := 1; i < len(s.Knots); i++ {
+		if s.Knots[i] < s.Knots[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// Domain returns the range of parameters the curve is defined for.
+func (s BSpline) Domain() (float64, float64) {
+	if !s.valid() {
+		return 0, 0
+	}
+	return s.Knots[s.Degree], s.Knots[len(s.Points)]
+}
+
+func (s BSpline) weight(i int) float64 {
+	if s.Weights == nil {
+		return 1
+	}
+	return s.Weights[i]
+}
+
+func (s BSpline) homogeneous() []hpoint {
+	h := make([]hpoint, len(s.Points))
+	for i, p := range s.Points {
+		h[i] = homogeneous(p, s.weight(i))
+	}
+	return h
+}
+
+// span returns the index k of the knot span [Knots[k], Knots[k+1]) holding
+// t, clamped to the domain so that its end belongs to the last span.
+func (s BSpline) span(t float64) int {
+	n := len(s.Points)
+	k := sort.Search(len(s.Knots), func(i int) bool { return s.Knots[i] > t }) - 1
+	k = min(max(k, s.Degree), n-1)
+	for k > s.Degree && s.Knots[k] == s.Knots[k+1] {
+		k--
+	}
+	return k
+}
+
+// blossom evaluates the polar form of the spline on span k at the given
+// parameters, one per degree. Passing t for every one gives the point at t,
+// which is de Boor's algorithm.
+func blossom(degree int, knots []float64, cps []hpoint, k int, ts []float64) hpoint {
+	d := append([]hpoint{}, cps[k-degree:k+1]...)
+	for r := 1; r <= degree; r++ {
+		for j := degree; j >= r; j-- {
+			i := j + k - degree
+			alpha := 0.0
+			if denom := knots[i+degree-r+1] - knots[i]; denom != 0 {
+				alpha = (ts[r-1] - knots[i]) / denom
+			}
+			d[j] = d[j-1].lerp(d[j], alpha)
+		}
+	}
+	return d[degree]
+}
+
+// splineEvaluator evaluates a B-spline and its first two derivatives, with
+// the control points of the derivative splines computed once.
+type splineEvaluator struct {
+	s      BSpline
+	points [3][]hpoint
+	knots  [3][]float64
+}
+
+func (s BSpline) evaluator() *splineEvaluator {
+	e := &splineEvaluator{s: s}
+	e.points[0], e.knots[0] = s.homogeneous(), s.Knots
+	for d := 1; d < 3; d++ {
+		degree := s.Degree - d + 1
+		prev, knots := e.points[d-1], e.knots[d-1]
+		if degree < 1 {
+			break
+		}
+		next := make([]hpoint, len(prev)-1)
+		for i := range next {
+			scale := 0.0
+			if denom := knots[i+degree+1] - knots[i+1]; denom != 0 {
+				scale = float64(degree) / denom
+			}
+			next[i] = hpoint{(prev[i+1].x - prev[i].x) * scale, (prev[i+1].y - prev[i].y) * scale, (prev[i+1].w - prev[i].w) * scale}
+		}
+		e.points[d], e.knots[d] = next, knots[1:len(knots)-1]
+	}
+	return e
+}
+
+// at returns the homogeneous point and derivatives of order up to two.
+func (e *splineEvaluator) at(t float64) [3]hpoint {
+	var out [3]hpoint
+	k := e.s.span(t)
+	for d := 0; d < 3; d++ {
+		degree := e.s.Degree - d
+		if degree < 0 || e.points[d] == nil {
+			break
+		}
+		ts := make([]float64, degree)
+		for i := range ts {
+			ts[i] = t
+		}
+		out[d] = blossom(degree, e.knots[d], e.points[d], k-d, ts)
+	}
+	return out
+}
+
+// sample returns the point of the curve and its derivatives at t, applying
+// the quotient rule to the homogeneous derivatives.
+func (e *splineEvaluator) sample(t float64) curveSample {
+	h := e.at(t)
+	w := h[0].w
+	c := vector2.Vector2{X: h[0].x / w, Y: h[0].y / w}
+	d1 := vector2.Vector2{X: (h[1].x - h[1].w*c.X) / w, Y: (h[1].y - h[1].w*c.Y) / w}
+	d2 := vector2.Vector2{
+		X: (h[2].x - 2*h[1].w*d1.X - h[2].w*c.X) / w,
+		Y: (h[2].y - 2*h[1].w*d1.Y - h[2].w*c.Y) / w,
+	}
+	return curveSample{c, d1, d2}
+}
+
+// PointAt returns the point of the curve at t, which is clamped to the
+// domain.
+func (s BSpline) PointAt(t float64) vector2.Vector2 {
+	if !s.valid() {
+		return vector2.Vector2{}
+	}
+	return s.evaluator().sample(s.clamp(t)).point
+}
+
+// Derivative returns the tangent of the curve at t, which is clamped to the
+// domain.
+func (s BSpline) Derivative(t float64) vector2.Vector2 {
+	if !s.valid() {
+		return vector2.Vector2{}
+	}
+	return s.evaluator().sample(s.clamp(t)).d1
+}
+
+// SecondDerivative returns the second derivative of the curve at t, which
+// is clamped to the domain.
+func (s BSpline) SecondDerivative(t float64) vector2.Vector2 {
+	if !s.valid() {
+		return vector2.Vector2{}
+	}
+	return s.evaluator().sample(s.clamp(t)).d2
+}
+
+func (s BSpline) clamp(t float64) float64 {
+	lo, hi := s.Domain()
+	return math.Min(hi, math.Max(lo, t))
+}
+
+// InsertKnot returns the same curve with the knot t added, and one more
+// control point, using Boehm's algorithm. t is clamped to the domain.
+func (s BSpline) InsertKnot(t float64) BSpline {
+	if lo, hi := s.Domain(); !s.valid() || lo == hi {
+		return s
+	}
+	p := s.Degree
+	t = s.clamp(t)
+	k := s.span(t)
+	h := s.homogeneous()
+	inserted := make([]hpoint, len(h)+1)
+	for i := range inserted {
+		switch {
+		case i <= k-p:
+			inserted[i] = h[i]
+		case i > k:
+			inserted[i] = h[i-1]
+		default:
+			alpha := (t - s.Knots[i]) / (s.Knots[i+p] - s.Knots[i])
+			inserted[i] = h[i-1].lerp(h[i], alpha)
+		}
+	}
+	knots := append(append(append([]float64{}, s.Knots[:k+1]...), t), s.Knots[k+1:]...)
+	return s.fromHomogeneous(inserted, knots)
+}
+
+// fromHomogeneous returns a spline of the same degree and kind with new
+// control points and knots.
+func (s BSpline) fromHomogeneous(h []hpoint, knots []float64) BSpline {
+	out := BSpline{Degree: s.Degree, Points: make([]vector2.Vector2, len(h)), Knots: knots}
+	if s.Weights != nil {
+		out.Weights = make([]float64, len(h))
+	}
+	for i, c := range h {
+		out.Points[i] = c.point()
+		if out.Weights != nil {
+			out.Weights[i] = c.w
+		}
+	}
+	return out
+}
+
+// Split divides the curve at t into the parts before and after it. Each
+// part keeps the original parameters, so the first is defined up to t and
+// the second from t. A t at or beyond an end of the domain leaves that part
+// as a single point.
+func (s BSpline) Split(t float64) (BSpline, BSpline) {
+	if !s.valid() {
+		return s, s
+	}
+	p := s.Degree
+	lo, hi := s.Domain()
+	t = s.clamp(t)
+	if t <= lo || t >= hi {
+		point := pointSpline(s, s.PointAt(t), t)
+		if t <= lo {
+			return point, s
+		}
+		return s, point
+	}
+	for multiplicity(s.Knots, t) < p {
+		s = s.InsertKnot(t)
+	}
+	// With t repeated Degree times, the control point before the run of t
+	// lies on the curve and ends the first part.
+	first := sort.SearchFloat64s(s.Knots, t)
+	shared := first - 1
+	left := s.slice(0, shared+1, append(append([]float64{}, s.Knots[:first+p]...), t))
+	right := s.slice(shared, len(s.Points), append([]float64{t}, s.Knots[first:]...))
+	return left, right
+}
+
+// slice returns the spline with control points [from, to) and new knots.
+func (s BSpline) slice(from, to int, knots []float64) BSpline {
+	out := BSpline{Degree: s.Degree, Points: append([]vector2.Vector2{}, s.Points[from:to]...), Knots: knots}
+	if s.Weights != nil {
+		out.Weights = append([]float64{}, s.Weights[from:to]...)
+	}
+	return out
+}
+
+// pointSpline returns a spline of the same degree and kind that stays at
+// point, defined only at parameter t.
+func pointSpline(s BSpline, point vector2.Vector2, t float64) BSpline {
+	out := BSpline{Degree: s.Degree, Points: make([]vector2.Vector2, s.Degree+1), Knots: make([]float64, 2*s.Degree+2)}
+	if s.Weights != nil {
+		out.Weights = make([]float64, s.Degree+1)
+	}
+	for i := range out.Points {
+		out.Points[i] = point
+		if out.Weights != nil {
+			out.Weights[i] = 1
+		}
+	}
+	for i := range out.Knots {
+		out.Knots[i] = t
+	}
+	return out
+}
+
+func multiplicity(knots []float64, t float64) int {
+	count := 0
+	for _, k := range knots {
+		if k == t {
+			count++
+		}
+	}
+	return count
+}
+
+// spans returns the parameter ranges of the non-empty knot spans.
+func (s BSpline) spans() [][2]float64 {
+	var spans [][2]float64
+	for k := s.Degree; k < len(s.Points); k++ {
+		if s.Knots[k] < s.Knots[k+1] {
+			spans = append(spans, [2]float64{s.Knots[k], s.Knots[k+1]})
+		}
+	}
+	return spans
+}
+
+// pieces returns the curve as one rational Bezier piece per knot span,
+// whose control points are the blossom at the span's ends.
+func (s BSpline) pieces() [][]hpoint {
+	if !s.valid() {
+		return nil
+	}
+	h := s.homogeneous()
+	var pieces [][]hpoint
+	for _, span := range s.spans() {
+		k := s.span(span[0])
+		piece := make([]hpoint, s.Degree+1)
+		ts := make([]float64, s.Degree)
+		for j := range piece {
+			for i := range ts {
+				ts[i] = span[0]
+				if i < j {
+					ts[i] = span[1]
+				}
+			}
+			piece[j] = blossom(s.Degree, s.Knots, h, k, ts)
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// Flatten returns the curve as an open polyline whose chords stay within
+// tolerance of it, with more points where it bends more. A tolerance of zero
+// selects DefaultTolerance.
+func (s BSpline) Flatten(tolerance float64) Polygon {
+	return flattenPieces(s.pieces(), tolerance)
+}
+
+func (s BSpline) Translate(offsetX, offsetY float64) Shape {
+	return s.Transform(algebra.Translation(offsetX, offsetY))
+}
+
+func (s BSpline) Scale(factor float64) Shape {
+	return s.Transform(algebra.Scaling(factor, factor))
+}
+
+// Transform maps the control points, which maps the curve exactly.
+func (s BSpline) Transform(m algebra.Affine) Shape {
+	points := make([]vector2.Vector2, len(s.Points))
+	for i, p := range s.Points {
+		points[i] = m.Apply(p)
+	}
+	s.Points = points
+	return s
+}
+
+// GetBoundingBox returns the bounds of the curve, found from the end points
+// and the points where the tangent is horizontal or vertical.
+func (s BSpline) GetBoundingBox() rect2.Rect2 {
+	if !s.valid() {
+		return rect2.Rect2{}
+	}
+	e := s.evaluator()
+	lo, hi := s.Domain()
+	rect := rect2.Rect2{Position: e.sample(lo).point}.Expand(e.sample(hi).point)
+	// Along each axis a span has at most 2p - 2 turning points, which 4p
+	// samples keep apart.
+	samples := 4 * s.Degree
+	for _, span := range s.spans() {
+		for _, t := range derivativeRoots(span[0], span[1], samples, func(t float64) float64 { return e.sample(t).d1.X }) {
+			rect = rect.Expand(e.sample(t).point)
+		}
+		for _, t := range derivativeRoots(span[0], span[1], samples, func(t float64) float64 { return e.sample(t).d1.Y }) {
+			rect = rect.Expand(e.sample(t).point)
+		}
+	}
+	return rect
+}
+
+func (s BSpline) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	drawPolyline(dc, s.Flatten(DefaultTolerance))
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (s BSpline) DrawDashed(dc *gg.Context, color [4]float64, lwidth, dashLength, dashInterval float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	drawPolyline(dc, s.Flatten(DefaultTolerance))
+	dc.SetDash(dashLength, dashInterval)
+	dc.Stroke()
+	dc.Pop()
+}
+
+// DrawFilled fills the area between the curve and its chord, which is the
+// area inside a closed curve.
+func (s BSpline) DrawFilled(dc *gg.Context, color [4]float64) {
+	dc.Push()
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	drawPolyline(dc, s.Flatten(DefaultTolerance))
+	dc.Fill()
+	dc.Pop()
+}
+
+// SignedDistance returns the distance to the curve. Like a line, a curve
+// has no inside, so the result is never negative.
+func (s BSpline) SignedDistance(p vector2.Vector2) float64 {
+	if !s.valid() {
+		return math.Inf(1)
+	}
+	return p.DistanceTo(s.closest(p).point)
+}
+
+// SampleDistance returns the distance to the curve with its nearest point.
+// On the curve the gradient is the normal to the left of the direction of
+// travel.
+func (s BSpline) SampleDistance(p vector2.Vector2) DistanceSample {
+	if !s.valid() {
+		return DistanceSample{Distance: math.Inf(1)}
+	}
+	return curveDistanceSample(p, s.closest(p))
+}
+
+// closest returns the sample of a valid spline nearest to p, searching each
+// knot span separately.
+func (s BSpline) closest(p vector2.Vector2) curveSample {
+	e := s.evaluator()
+	lo, _ := s.Domain()
+	best := e.sample(lo)
+	for _, span := range s.spans() {
+		if c := e.sample(closestOnCurve(p, span[0], span[1], closestSamples, e.sample)); p.DistanceSquaredTo(c.point) < p.DistanceSquaredTo(best.point) {
+			best = c
+		}
+	}
+	return best
+}
+
+// Contains tests the point against the area between the curve and its
+// chord, which is the area inside a closed curve.
+func (s BSpline) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	outline := s.Flatten(outlineTolerance(s.GetBoundingBox(), opts))
+	return curveLocate(p, s.SignedDistance(p), outline, opts).contained(opts)
+}
@@ -0,0 +1,98 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// quarterCircle is the unit circle from (1, 0) to (0, 1) as a quadratic
+// NURBS.
+func quarterCircle() BSpline {
+	points := []vector2.Vector2{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+	return NewNURBS(2, points, []float64{1, math.Sqrt2 / 2, 1}, []float64{0, 0, 0, 1, 1, 1})
+}
+
+func TestBSplineMatchesBezier(t *testing.T) {
+	b := NewCubicBezier(0, 0, 1, 3, 4, 3, 5, 0)
+	s := NewUniformBSpline(3, []vector2.Vector2{b.Start, b.Control1, b.Control2, b.End})
+	for _, u := range []float64{0, 0.25, 0.5, 0.8, 1} {
+		if got, want := s.PointAt(u), b.PointAt(u); got.DistanceTo(want) > 1e-12 {
+			t.Errorf("PointAt(%g) = %v, want %v", u, got, want)
+		}
+		if got, want := s.Derivative(u), b.Derivative(u); got.DistanceTo(want) > 1e-9 {
+			t.Errorf("Derivative(%g) = %v, want %v", u, got, want)
+		}
+	}
+}
+
+func TestNURBSCircle(t *testing.T) {
+	s := quarterCircle()
+	for k := 0; k <= 20; k++ {
+		p := s.PointAt(float64(k) / 20)
+		if r := p.Length(); math.Abs(r-1) > 1e-12 {
+			t.Fatalf("point %v has radius %g, want 1", p, r)
+		}
+	}
+	for _, p := range s.Flatten(0.001) {
+		if r := p.Length(); math.Abs(r-1) > 1e-12 {
+			t.Fatalf("flattened point %v has radius %g, want 1", p, r)
+		}
+	}
+	checkFlatten(t, "quarter circle", s.PointAt, 0, 1, 0.001, s.Flatten(0.001))
+
+	if got := s.SignedDistance(vector2.Vector2{X: 2, Y: 2}); math.Abs(got-(2*math.Sqrt2-1)) > 1e-9 {
+		t.Errorf("SignedDistance = %g, want %g", got, 2*math.Sqrt2-1)
+	}
+}
+
+func TestBSplineKnotsKeepTheCurve(t *testing.T) {
+	points := []vector2.Vector2{{X: 0, Y: 0}, {X: 1, Y: 4}, {X: 3, Y: -2}, {X: 5, Y: 3}, {X: 7, Y: 0}, {X: 8, Y: 2}}
+	s := NewUniformBSpline(3, points)
+	inserted := s.InsertKnot(0.4)
+	if len(inserted.Points) != len(points)+1 {
+		t.Fatalf("got %d control points, want %d", len(inserted.Points), len(points)+1)
+	}
+	left, right := s.Split(0.6)
+	for k := 0; k <= 50; k++ {
+		u := float64(k) / 50
+		want := s.PointAt(u)
+		if got := inserted.PointAt(u); got.DistanceTo(want) > 1e-12 {
+			t.Fatalf("after InsertKnot, PointAt(%g) = %v, want %v", u, got, want)
+		}
+		part := left
+		if u > 0.6 {
+			part = right
+		}
+		if got := part.PointAt(u); got.DistanceTo(want) > 1e-12 {
+			t.Fatalf("after Split, PointAt(%g) = %v, want %v", u, got, want)
+		}
+	}
+	if lo, hi := left.Domain(); lo != 0 || hi != 0.6 {
+		t.Errorf("first part spans [%g, %g], want [0, 0.6]", lo, hi)
+	}
+
+	for _, tolerance := range []float64{0.1, 0.001} {
+		checkFlatten(t, "cubic spline", s.PointAt, 0, 1, tolerance, s.Flatten(tolerance))
+	}
+
+	box := s.GetBoundingBox()
+	for k := 0; k <= 200; k++ {
+		p := s.PointAt(float64(k) / 200)
+		if p.X < box.Position.X-1e-9 || p.X > box.Position.X+box.Size.X+1e-9 ||
+			p.Y < box.Position.Y-1e-9 || p.Y > box.Position.Y+box.Size.Y+1e-9 {
+			t.Fatalf("curve point %v lies outside %v", p, box)
+		}
+	}
+}
+
+func TestBSplineInvalid(t *testing.T) {
+	s := NewBSpline(3, []vector2.Vector2{{X: 0, Y: 0}, {X: 1, Y: 1}}, []float64{0, 1})
+	if got := s.PointAt(0.5); got != (vector2.Vector2{}) {
+		t.Errorf("PointAt on an invalid spline = %v, want the origin", got)
+	}
+	if got := s.Flatten(0.1); len(got) != 0 {
+		t.Errorf("Flatten on an invalid spline = %v, want nothing", got)
+	}
+}
@@ -30,7 +30,7 @@ func (c Circle) Scale(factor float64) Shape {
 }
 
 func (c Circle) GetBoundingBox() rect2.Rect2 {
-	position := vector2.Vector2{X: 0.0, Y: 0.0}
+	position := c.Center.Subf(c.Radius)
 	size := vector2.Vector2{X: c.Radius * 2, Y: c.Radius * 2}
 	rect := rect2.Rect2{Position: position, Size: size}
 
@@ -64,7 +64,18 @@ func (c Circle) DrawFilled(dc *gg.Context, color [4]float64) {
 	dc.Pop()
 }
 
-func (c Circle) SignedDistance(x, y int) float64 {
-	p := vector2.Vector2{X: float64(x), Y: float64(y)}
+func (c Circle) SignedDistance(p vector2.Vector2) float64 {
 	return p.Sub(c.Center).Length() - c.Radius
 }
+
+// SampleDistance returns the signed distance with the nearest point of the
+// circle. The center is equally far from every point, and reports the one
+// at angle zero.
+func (c Circle) SampleDistance(p vector2.Vector2) DistanceSample {
+	direction := p.Sub(c.Center).Normalized()
+	if direction.X == 0 && direction.Y == 0 {
+		direction = vector2.Vector2{X: 1}
+	}
+	closest := c.Center.Add(direction.Mulf(c.Radius))
+	return newDistanceSample(p, closest, p.DistanceTo(c.Center) < c.Radius, direction)
+}
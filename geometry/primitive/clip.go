@@ -0,0 +1,189 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	clipper "github.com/ctessum/go.clipper"
+)
+
+// ClipScale is the fixed point scale used when handing vertices to clipper.
+// Coordinates are exact to 1/ClipScale units. Clipper switches to much slower
+// big integer arithmetic once a scaled coordinate passes 2^30, so the scale
+// trades precision for range: at 1e5 coordinates within about ±10,700 units,
+// over ten metres in millimetres, stay on the fast path with 10 nm precision.
+// Larger coordinates are still handled correctly, only more slowly.
+const ClipScale = 100000.0
+
+// DefaultTolerance is the maximum chord error used when curved shapes have to
+// be turned into polygons for the vector boolean engine.
+const DefaultTolerance = 0.01
+
+// ClipPolygons performs a vector boolean operation between the subject and clip
+// polygons. Both sets are interpreted with the nonzero fill rule. The result
+// contains outer rings with a positive signed area and hole rings with a
+// negative one.
+func ClipPolygons(subject, clip []Polygon, op BooleanOperation) []Polygon {
+	return clipRings(subject, NonZero, clip, NonZero, op)
+}
+
+// ClipRegions performs a vector boolean operation between two regions, each
+// read with its own fill rule. The result is normalized.
+func ClipRegions(subject, clip Region, op BooleanOperation) Region {
+	return Region{Rings: clipRings(subject.Rings, subject.Rule, clip.Rings, clip.Rule, op)}
+}
+
+func clipRings(subject []Polygon, subjectRule FillRule, clip []Polygon, clipRule FillRule, op BooleanOperation) []Polygon {
+	c := clipper.NewClipper(clipper.IoNone)
+	for _, p := range subject {
+		if len(p) > 2 {
+			c.AddPath(toClipperPath(p), clipper.PtSubject, true)
+		}
+	}
+	for _, p := range clip {
+		if len(p) > 2 {
+			c.AddPath(toClipperPath(p), clipper.PtClip, true)
+		}
+	}
+	solution, ok := c.Execute1(toClipType(op), toClipperFill(subjectRule), toClipperFill(clipRule))
+	if !ok {
+		return []Polygon{}
+	}
+	return fromClipperPaths(solution)
+}
+
+// Union returns the union of two polygons.
+func (p Polygon) Union(other Polygon) []Polygon {
+	return ClipPolygons([]Polygon{p}, []Polygon{other}, Union)
+}
+
+// Difference returns p with other removed from it.
+func (p Polygon) Difference(other Polygon) []Polygon {
+	return ClipPolygons([]Polygon{p}, []Polygon{other}, Difference)
+}
+
+// Intersection returns the area shared by both polygons.
+func (p Polygon) Intersection(other Polygon) []Polygon {
+	return ClipPolygons([]Polygon{p}, []Polygon{other}, Intersection)
+}
+
+// Xor returns the area covered by exactly one of the polygons.
+func (p Polygon) Xor(other Polygon) []Polygon {
+	return ClipPolygons([]Polygon{p}, []Polygon{other}, Xor)
+}
+
+// Polygonize converts a shape into a polygon. Straight edged shapes are
+// converted exactly, curved ones are sampled so that no chord deviates from
+// the true outline by more than tolerance.
+func Polygonize(s Shape, tolerance float64) Polygon {
+	switch s := s.(type) {
+	case Polygon:
+		return s
+	case Rectangle:
+		return NewPolygon(
+			s.Offset,
+			vector2.New(s.Offset.X+s.Size.X, s.Offset.Y),
+			s.Offset.Add(s.Size),
+			vector2.New(s.Offset.X, s.Offset.Y+s.Size.Y),
+		)
+	case Circle:
+		a := Arc{Circle: s, AngleStart: 0, AngleEnd: 2 * math.Pi}
+		points := a.Discretize(chordInterval(s.Radius, tolerance), 8)
+		return Polygon(points[:len(points)-1])
+	case Arc:
+		return Polygon(s.Discretize(chordInterval(s.Circle.Radius, tolerance), 3))
+	case Ellipse:
+		return s.polygon(tolerance)
+	case EllipticalArc:
+		return Polygon(s.points(tolerance))
+	case curve:
+		return flattenPieces(s.pieces(), tolerance)
+	case Region:
+		// A single polygon cannot hold holes; keep the largest outline.
+		return largestPolygon(s.Normalized().Rings)
+	}
+	return Polygon{}
+}
+
+// chordInterval returns the arc length between samples on a circle of the
+// given radius that keeps the chord error below tolerance.
+func chordInterval(radius, tolerance float64) float64 {
+	if tolerance <= 0 || tolerance >= radius {
+		return radius
+	}
+	return radius * 2 * math.Acos(1-tolerance/radius)
+}
+
+func largestPolygon(polygons []Polygon) Polygon {
+	largest := Polygon{}
+	maxArea := 0.0
+	for _, p := range polygons {
+		if a := signedArea(p); a > maxArea {
+			maxArea = a
+			largest = p
+		}
+	}
+	return largest
+}
+
+// signedArea returns the shoelace area of the ring, positive for
+// counter-clockwise rings in a y-up frame.
+func signedArea(ring []vector2.Vector2) float64 {
+	area := 0.0
+	n := len(ring)
+	for i := 0; i < n; i++ {
+		j := (i + 1) % n
+		area += ring[i].X*ring[j].Y - ring[j].X*ring[i].Y
+	}
+	return area / 2
+}
+
+func toClipType(op BooleanOperation) clipper.ClipType {
+	switch op {
+	case Difference:
+		return clipper.CtDifference
+	case Intersection:
+		return clipper.CtIntersection
+	case Xor:
+		return clipper.CtXor
+	}
+	return clipper.CtUnion
+}
+
+func toClipperFill(rule FillRule) clipper.PolyFillType {
+	if rule == EvenOdd {
+		return clipper.PftEvenOdd
+	}
+	return clipper.PftNonZero
+}
+
+func toFixedPoint(v vector2.Vector2) *clipper.IntPoint {
+	return clipper.NewIntPoint(clipper.CInt(math.Round(v.X*ClipScale)), clipper.CInt(math.Round(v.Y*ClipScale)))
+}
+
+func fromFixedPoint(p *clipper.IntPoint) vector2.Vector2 {
+	return vector2.Vector2{X: float64(p.X) / ClipScale, Y: float64(p.Y) / ClipScale}
+}
+
+func toClipperPath(points []vector2.Vector2) clipper.Path {
+	path := make(clipper.Path, 0, len(points))
+	for _, p := range points {
+		path = append(path, toFixedPoint(p))
+	}
+	return path
+}
+
+func fromClipperPaths(paths clipper.Paths) []Polygon {
+	polygons := make([]Polygon, 0, len(paths))
+	for _, path := range paths {
+		if len(path) < 3 {
+			continue
+		}
+		polygon := make(Polygon, 0, len(path))
+		for _, pt := range path {
+			polygon = append(polygon, fromFixedPoint(pt))
+		}
+		polygons = append(polygons, polygon)
+	}
+	return polygons
+}
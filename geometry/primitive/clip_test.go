@@ -0,0 +1,65 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func totalArea(rings []Polygon) float64 {
+	area := 0.0
+	for _, ring := range rings {
+		area += signedArea(ring)
+	}
+	return area
+}
+
+func TestClipPolygonsAreas(t *testing.T) {
+	a, b := square(0, 0, 10), square(5, 5, 10)
+	for _, tc := range []struct {
+		op   BooleanOperation
+		area float64
+	}{
+		{Union, 175},
+		{Difference, 75},
+		{Intersection, 25},
+		{Xor, 150},
+	} {
+		if got := totalArea(ClipPolygons([]Polygon{a}, []Polygon{b}, tc.op)); math.Abs(got-tc.area) > 1e-6 {
+			t.Errorf("op %v: area = %g, want %g", tc.op, got, tc.area)
+		}
+	}
+}
+
+func TestMeshSubtractKeepsHole(t *testing.T) {
+	m := NewMesh()
+	m.Region = NewRegion(NonZero, square(0, 0, 10))
+	m.SubtractShape(square(2, 2, 6))
+	if len(m.Region.Rings) != 2 {
+		t.Fatalf("got %d rings, want an outline and a hole", len(m.Region.Rings))
+	}
+	if area := m.Region.Area(); math.Abs(area-64) > 1e-6 {
+		t.Errorf("area = %g, want 64", area)
+	}
+	if holes := len(m.Region.Rings) - len(m.Region.Outers()); holes != 1 {
+		t.Errorf("got %d holes, want 1", holes)
+	}
+
+	m.IntersectShape(square(-5, -5, 10))
+	if area := m.Region.Area(); math.Abs(area-16) > 1e-6 {
+		t.Errorf("area after intersection = %g, want 16", area)
+	}
+}
+
+func TestClipScaleKeepsPartsInFastRange(t *testing.T) {
+	// Clipper's 64 bit fast path covers scaled coordinates up to 0x3FFFFFFF.
+	const loRange = 0x3FFFFFFF
+	p := toFixedPoint(vector2.Vector2{X: 10000, Y: -10000})
+	if p.X > loRange || -p.Y > loRange {
+		t.Errorf("a 10 m part in millimetres scales to %v, past the fast range", p)
+	}
+	if got := fromFixedPoint(toFixedPoint(vector2.Vector2{X: 1.23456})); got.X != 1.23456 {
+		t.Errorf("got %v, want coordinates kept to 1/ClipScale", got)
+	}
+}
@@ -0,0 +1,317 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/geometry2d"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// ArcRegion selects the area an Arc encloses for containment tests.
+type ArcRegion int
+
+const (
+	// ArcSector is the pie slice between the arc and its center.
+	ArcSector ArcRegion = iota
+	// ArcSegment is the area between the arc and its chord.
+	ArcSegment
+)
+
+// ContainOptions configures point containment tests.
+type ContainOptions struct {
+	// IncludeBoundary counts points on the outline as contained.
+	IncludeBoundary bool
+	// Tolerance is the distance from the outline within which a point is
+	// treated as on the boundary. Zero requires an exact hit.
+	Tolerance float64
+	// Rule is the fill rule for self-overlapping Polygons. Regions always use
+	// their own rule.
+	Rule FillRule
+	// Arc is the area enclosed by an Arc.
+	Arc ArcRegion
+}
+
+// location classifies a point against a shape.
+type location int
+
+const (
+	outside location = iota
+	inside
+	boundary
+)
+
+func (l location) contained(opts ContainOptions) bool {
+	return l == inside || (l == boundary && opts.IncludeBoundary)
+}
+
+// onBoundary reports whether a signed distance puts a point on the outline.
+func onBoundary(d float64, opts ContainOptions) bool {
+	return math.Abs(d) <= opts.Tolerance
+}
+
+func (c Circle) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	return c.locate(p, opts).contained(opts)
+}
+
+func (c Circle) locate(p vector2.Vector2, opts ContainOptions) location {
+	d := p.DistanceTo(c.Center) - c.Radius
+	if onBoundary(d, opts) {
+		return boundary
+	}
+	if d < 0 {
+		return inside
+	}
+	return outside
+}
+
+func (e Ellipse) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	local := rotate(p.Sub(e.Center), -e.Rotation)
+	d := local.DistanceTo(ellipseClosestPoint(local, e.Radii.X, e.Radii.Y))
+	if onBoundary(d, opts) {
+		return opts.IncludeBoundary
+	}
+	if e.Radii.X <= 0 || e.Radii.Y <= 0 {
+		return false
+	}
+	return (local.X*local.X)/(e.Radii.X*e.Radii.X)+(local.Y*local.Y)/(e.Radii.Y*e.Radii.Y) < 1
+}
+
+func (r Rectangle) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	rect := r.GetBoundingBox()
+	end := rect.Position.Add(rect.Size)
+	// Distance to the nearest edge, negative inside.
+	dx := math.Max(rect.Position.X-p.X, p.X-end.X)
+	dy := math.Max(rect.Position.Y-p.Y, p.Y-end.Y)
+	d := math.Max(dx, dy)
+	if dx > 0 && dy > 0 {
+		d = math.Hypot(dx, dy)
+	}
+	if onBoundary(d, opts) {
+		return opts.IncludeBoundary
+	}
+	return d < 0
+}
+
+// Contains tests the point against the polygon with opts.Rule.
+func (p Polygon) Contains(point vector2.Vector2, opts ContainOptions) bool {
+	if len(p) == 0 {
+		return false
+	}
+	return locateRings([]Polygon{p}, opts.Rule, point, opts).contained(opts)
+}
+
+// Contains tests the point against the region with the region's fill rule.
+func (r Region) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	return locateRings(r.Rings, r.Rule, p, opts).contained(opts)
+}
+
+func locateRings(rings []Polygon, fill FillRule, p vector2.Vector2, opts ContainOptions) location {
+	winding := 0
+	for _, ring := range rings {
+		n := len(ring)
+		for i := 0; i < n; i++ {
+			if onSegment(p, ring[i], ring[(i+1)%n], opts.Tolerance) {
+				return boundary
+			}
+		}
+		winding += windingNumber(ring, p)
+	}
+	if insideWinding(winding, fill) {
+		return inside
+	}
+	return outside
+}
+
+// onSegment reports whether p lies within tolerance of segment ab. With a
+// zero tolerance the point has to be exactly collinear and between the
+// endpoints.
+func onSegment(p, a, b vector2.Vector2, tolerance float64) bool {
+	if tolerance > 0 {
+		return geometry2d.GetDistanceSquaredToSegment(p, [2]vector2.Vector2{a, b}) <= tolerance*tolerance
+	}
+	ab, ap := b.Sub(a), p.Sub(a)
+	if ab.X == 0 && ab.Y == 0 {
+		return p == a
+	}
+	if ab.Cross(ap) != 0 {
+		return false
+	}
+	t := ap.Dot(ab)
+	return t >= 0 && t <= ab.Dot(ab)
+}
+
+// Contains reports whether the point is on the line. A line encloses no
+// area, so only boundary points are ever contained.
+func (l Line) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	return opts.IncludeBoundary && onSegment(p, l.Start, l.End, opts.Tolerance)
+}
+
+// Contains tests the point against the sector or segment of the arc chosen
+// by opts.Arc.
+func (a Arc) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	return a.locate(p, opts).contained(opts)
+}
+
+func (a Arc) locate(p vector2.Vector2, opts ContainOptions) location {
+	if math.Abs(a.Sweep()) >= 2*math.Pi {
+		return a.Circle.locate(p, opts)
+	}
+	start, end := a.StartPoint(), a.EndPoint()
+	d := p.DistanceTo(a.Circle.Center) - a.Circle.Radius
+	onCurve := onBoundary(d, opts) && a.withinSweep(p)
+	if onCurve {
+		return boundary
+	}
+	if opts.Arc == ArcSegment {
+		// The segment is the part of the disc on the same side of the chord
+		// as the middle of the arc.
+		if onSegment(p, start, end, opts.Tolerance) {
+			return boundary
+		}
+		mid := a.PointAt(a.AngleStart + a.Sweep()/2)
+		chord := end.Sub(start)
+		side := chord.Cross(p.Sub(start)) * chord.Cross(mid.Sub(start))
+		if d < 0 && side > 0 {
+			return inside
+		}
+		return outside
+	}
+	center := a.Circle.Center
+	if onSegment(p, center, start, opts.Tolerance) || onSegment(p, center, end, opts.Tolerance) {
+		return boundary
+	}
+	if d < 0 && a.withinSweep(p) {
+		return inside
+	}
+	return outside
+}
+
+// withinSweep reports whether the direction from the center to p falls inside
+// the angular range swept by the arc.
+func (a Arc) withinSweep(p vector2.Vector2) bool {
+	sweep := a.Sweep()
+	if math.Abs(sweep) >= 2*math.Pi {
+		return true
+	}
+	angle := a.Circle.Center.AngleToPoint(p)
+	rel := angle - a.AngleStart
+	if sweep < 0 {
+		rel, sweep = -rel, -sweep
+	}
+	rel = math.Mod(rel, 2*math.Pi)
+	if rel < 0 {
+		rel += 2 * math.Pi
+	}
+	return rel <= sweep
+}
+
+// ContainsPoints tests many points against one shape. Points outside the
+// shape's bounds are rejected without further work, and polygon edges are
+// bucketed into horizontal bands so that each point is only tested against
+// the edges that span its height. A polygon or region without vertices
+// contains nothing.
+func ContainsPoints(s Shape, points []vector2.Vector2, opts ContainOptions) []bool {
+	result := make([]bool, len(points))
+	switch s := s.(type) {
+	case Polygon:
+		if len(s) == 0 {
+			return result
+		}
+	case Region:
+		if !hasVertices(s.Rings) {
+			return result
+		}
+	}
+	bounds := s.GetBoundingBox().Grow(opts.Tolerance)
+	end := bounds.Position.Add(bounds.Size)
+	outsideBounds := func(p vector2.Vector2) bool {
+		return p.X < bounds.Position.X || p.Y < bounds.Position.Y || p.X > end.X || p.Y > end.Y
+	}
+
+	var index *edgeIndex
+	fill := opts.Rule
+	switch s := s.(type) {
+	case Polygon:
+		index = newEdgeIndex([]Polygon{s})
+	case Region:
+		index = newEdgeIndex(s.Rings)
+		fill = s.Rule
+	}
+
+	for i, p := range points {
+		if outsideBounds(p) {
+			continue
+		}
+		if index != nil {
+			result[i] = index.locate(p, fill, opts).contained(opts)
+		} else {
+			result[i] = s.Contains(p, opts)
+		}
+	}
+	return result
+}
+
+func hasVertices(rings []Polygon) bool {
+	for _, ring := range rings {
+		if len(ring) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// edgeIndex buckets ring edges into horizontal bands of equal height.
+type edgeIndex struct {
+	minY, bandHeight float64
+	bands            [][][2]vector2.Vector2
+}
+
+func newEdgeIndex(rings []Polygon) *edgeIndex {
+	var edges [][2]vector2.Vector2
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, ring := range rings {
+		n := len(ring)
+		for i := 0; i < n; i++ {
+			edges = append(edges, [2]vector2.Vector2{ring[i], ring[(i+1)%n]})
+			minY = math.Min(minY, ring[i].Y)
+			maxY = math.Max(maxY, ring[i].Y)
+		}
+	}
+	count := int(math.Max(1, math.Sqrt(float64(len(edges)))))
+	index := &edgeIndex{minY: minY, bands: make([][][2]vector2.Vector2, count)}
+	index.bandHeight = (maxY - minY) / float64(count)
+	if index.bandHeight <= 0 {
+		index.bandHeight = 1
+	}
+	for _, e := range edges {
+		lo := index.band(math.Min(e[0].Y, e[1].Y))
+		hi := index.band(math.Max(e[0].Y, e[1].Y))
+		for b := lo; b <= hi; b++ {
+			index.bands[b] = append(index.bands[b], e)
+		}
+	}
+	return index
+}
+
+func (x *edgeIndex) band(y float64) int {
+	b := int((y - x.minY) / x.bandHeight)
+	return max(0, min(len(x.bands)-1, b))
+}
+
+func (x *edgeIndex) locate(p vector2.Vector2, fill FillRule, opts ContainOptions) location {
+	for b := x.band(p.Y - opts.Tolerance); b <= x.band(p.Y+opts.Tolerance); b++ {
+		for _, e := range x.bands[b] {
+			if onSegment(p, e[0], e[1], opts.Tolerance) {
+				return boundary
+			}
+		}
+	}
+	winding := 0
+	for _, e := range x.bands[x.band(p.Y)] {
+		winding += edgeWinding(e[0], e[1], p)
+	}
+	if insideWinding(winding, fill) {
+		return inside
+	}
+	return outside
+}
@@ -0,0 +1,158 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func TestContainsFillRules(t *testing.T) {
+	// The pentagram winds twice around its center.
+	var star Polygon
+	for i := 0; i < 5; i++ {
+		angle := math.Pi/2 + float64(i)*4*math.Pi/5
+		star = append(star, vector2.Vector2{X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)})
+	}
+	center, tip := vector2.Vector2{}, vector2.Vector2{X: 0, Y: 8}
+	for _, tt := range []struct {
+		rule        FillRule
+		center, tip bool
+	}{
+		{NonZero, true, true},
+		{EvenOdd, false, true},
+	} {
+		opts := ContainOptions{Rule: tt.rule}
+		if got := star.Contains(center, opts); got != tt.center {
+			t.Errorf("rule %v: center contained = %v, want %v", tt.rule, got, tt.center)
+		}
+		if got := star.Contains(tip, opts); got != tt.tip {
+			t.Errorf("rule %v: tip contained = %v, want %v", tt.rule, got, tt.tip)
+		}
+	}
+
+	// Regions use their own rule, whatever opts.Rule says.
+	nested := []Polygon{square(0, 0, 10), square(2, 2, 6)}
+	p := vector2.Vector2{X: 5, Y: 5}
+	if !NewRegion(NonZero, nested...).Contains(p, ContainOptions{Rule: EvenOdd}) {
+		t.Error("nonzero region does not contain a point inside both rings")
+	}
+	if NewRegion(EvenOdd, nested...).Contains(p, ContainOptions{}) {
+		t.Error("even-odd region contains a point inside both rings")
+	}
+}
+
+func TestContainsBoundaryTolerance(t *testing.T) {
+	shapes := []Shape{
+		square(0, 0, 10),
+		NewRegion(NonZero, square(0, 0, 10)),
+		NewRectangle(0, 0, 10, 10),
+		NewCircle(5, 5, 5),
+	}
+	near := vector2.Vector2{X: 5, Y: -0.05}
+	on := vector2.Vector2{X: 5, Y: 0}
+	for _, s := range shapes {
+		for _, tt := range []struct {
+			p         vector2.Vector2
+			opts      ContainOptions
+			contained bool
+		}{
+			{near, ContainOptions{IncludeBoundary: true, Tolerance: 0.1}, true},
+			{near, ContainOptions{IncludeBoundary: false, Tolerance: 0.1}, false},
+			{near, ContainOptions{IncludeBoundary: true, Tolerance: 0.01}, false},
+			{on, ContainOptions{IncludeBoundary: true}, true},
+			{on, ContainOptions{}, false},
+		} {
+			if got := s.Contains(tt.p, tt.opts); got != tt.contained {
+				t.Errorf("%T: Contains(%v, %+v) = %v, want %v", s, tt.p, tt.opts, got, tt.contained)
+			}
+		}
+	}
+
+	line := Line{Start: vector2.Vector2{}, End: vector2.Vector2{X: 10}}
+	if line.Contains(vector2.Vector2{X: 5}, ContainOptions{}) {
+		t.Error("line contains a point without IncludeBoundary")
+	}
+	if !line.Contains(near, ContainOptions{IncludeBoundary: true, Tolerance: 0.1}) {
+		t.Error("line does not contain a point within tolerance")
+	}
+}
+
+func TestContainsArcModes(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		arc             Arc
+		p               vector2.Vector2
+		sector, segment bool
+	}{
+		{"between center and chord", NewArc(0, 0, 10, 0, math.Pi/2), vector2.Vector2{X: 2, Y: 2}, true, false},
+		{"between chord and arc", NewArc(0, 0, 10, 0, math.Pi/2), vector2.Vector2{X: 6, Y: 6}, true, true},
+		{"outside the sweep", NewArc(0, 0, 10, 0, math.Pi/2), vector2.Vector2{X: 6, Y: -6}, false, false},
+		{"clockwise", NewArc(0, 0, 10, 0, -math.Pi/2), vector2.Vector2{X: 6, Y: -6}, true, true},
+		{"clockwise outside", NewArc(0, 0, 10, 0, -math.Pi/2), vector2.Vector2{X: 6, Y: 6}, false, false},
+		{"major segment", NewArc(0, 0, 10, 0, 3*math.Pi/2), vector2.Vector2{X: -2, Y: -2}, true, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.arc.Contains(tt.p, ContainOptions{Arc: ArcSector}); got != tt.sector {
+				t.Errorf("sector contains %v = %v, want %v", tt.p, got, tt.sector)
+			}
+			if got := tt.arc.Contains(tt.p, ContainOptions{Arc: ArcSegment}); got != tt.segment {
+				t.Errorf("segment contains %v = %v, want %v", tt.p, got, tt.segment)
+			}
+		})
+	}
+
+	arc := NewArc(0, 0, 10, 0, math.Pi/2)
+	chord := vector2.Vector2{X: 5, Y: 5}
+	radius := vector2.Vector2{X: 5, Y: 0}
+	if arc.Contains(chord, ContainOptions{Arc: ArcSegment}) || !arc.Contains(chord, ContainOptions{Arc: ArcSegment, IncludeBoundary: true}) {
+		t.Error("chord point is not on the segment boundary")
+	}
+	if !arc.Contains(chord, ContainOptions{Arc: ArcSector}) {
+		t.Error("chord point is not inside the sector")
+	}
+	if arc.Contains(radius, ContainOptions{Arc: ArcSector}) || !arc.Contains(radius, ContainOptions{Arc: ArcSector, IncludeBoundary: true}) {
+		t.Error("radius point is not on the sector boundary")
+	}
+}
+
+func TestContainsPointsMatchesContains(t *testing.T) {
+	hole := square(3, 3, 4)
+	reverse(hole)
+	shapes := []Shape{
+		Polygon{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 5, Y: 4}, {X: 0, Y: 10}},
+		NewRegion(NonZero, square(0, 0, 10), hole),
+		NewRegion(EvenOdd, square(0, 0, 10), square(2, 2, 6)),
+		NewCircle(5, 5, 4),
+		NewArc(5, 5, 4, 0.5, 4),
+		NewRectangle(1, 1, 8, 6),
+	}
+	var points []vector2.Vector2
+	for x := -1.0; x <= 11; x += 0.25 {
+		for y := -1.0; y <= 11; y += 0.25 {
+			points = append(points, vector2.Vector2{X: x, Y: y})
+		}
+	}
+	for _, s := range shapes {
+		for _, opts := range []ContainOptions{
+			{},
+			{IncludeBoundary: true},
+			{IncludeBoundary: true, Tolerance: 0.2, Rule: EvenOdd, Arc: ArcSegment},
+		} {
+			got := ContainsPoints(s, points, opts)
+			for i, p := range points {
+				if want := s.Contains(p, opts); got[i] != want {
+					t.Errorf("%T %+v: point %v = %v, want %v", s, opts, p, got[i], want)
+				}
+			}
+		}
+	}
+
+	for _, s := range []Shape{Polygon{}, Region{}, NewRegion(NonZero, Polygon{})} {
+		for i, got := range ContainsPoints(s, points, ContainOptions{IncludeBoundary: true}) {
+			if got {
+				t.Fatalf("%T without vertices contains %v", s, points[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/geometry2d"
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/fogleman/gg"
+)
+
+// maxFlattenDepth bounds the subdivision of a single Bezier piece.
+const maxFlattenDepth = 16
+
+// closestSamples is the number of samples per piece that seed the search for
+// the nearest point of a curve.
+const closestSamples = 16
+
+// curve is a shape made of polynomial or rational Bezier pieces.
+type curve interface {
+	Shape
+	pieces() [][]hpoint
+}
+
+// hpoint is a control point in homogeneous coordinates: its position scaled
+// by its weight, and the weight. Polynomial curves have unit weights.
+type hpoint struct {
+	x, y, w float64
+}
+
+func homogeneous(v vector2.Vector2, w float64) hpoint {
+	return hpoint{v.X * w, v.Y * w, w}
+}
+
+func (h hpoint) point() vector2.Vector2 {
+	return vector2.Vector2{X: h.x / h.w, Y: h.y / h.w}
+}
+
+func (h hpoint) lerp(o hpoint, t float64) hpoint {
+	return hpoint{h.x + (o.x-h.x)*t, h.y + (o.y-h.y)*t, h.w + (o.w-h.w)*t}
+}
+
+// splitBezier splits a Bezier piece at t with de Casteljau's algorithm.
+func splitBezier(cps []hpoint, t float64) ([]hpoint, []hpoint) {
+	n := len(cps)
+	left, right := make([]hpoint, n), make([]hpoint, n)
+	work := append([]hpoint{}, cps...)
+	for level := 0; level < n; level++ {
+		left[level] = work[0]
+		right[n-1-level] = work[n-1-level]
+		for i := 0; i < n-1-level; i++ {
+			work[i] = work[i].lerp(work[i+1], t)
+		}
+	}
+	return left, right
+}
+
+// flattenBezier subdivides a Bezier piece until every control point lies
+// within tolerance of the chord and calls emit with each flat piece in
+// order. The curve stays inside the hull of its control points, so the
+// pieces are within tolerance of it as long as the weights are positive.
+func flattenBezier(cps []hpoint, tolerance float64, depth int, emit func([]hpoint)) {
+	start, end := cps[0].point(), cps[len(cps)-1].point()
+	flat := true
+	for _, c := range cps[1 : len(cps)-1] {
+		if geometry2d.GetDistanceToSegment(c.point(), [2]vector2.Vector2{start, end}) > tolerance {
+			flat = false
+			break
+		}
+	}
+	if flat || depth >= maxFlattenDepth {
+		emit(cps)
+		return
+	}
+	left, right := splitBezier(cps, 0.5)
+	flattenBezier(left, tolerance, depth+1, emit)
+	flattenBezier(right, tolerance, depth+1, emit)
+}
+
+// curveSample is a point of a curve with its first two derivatives.
+type curveSample struct {
+	point, d1, d2 vector2.Vector2
+}
+
+// closestOnCurve returns the parameter in [lo, hi] of the point of a curve
+// nearest to p. Every local minimum among evenly spaced samples is refined
+// with Newton's method on the squared distance, so the search finds the
+// global minimum unless two minima fall between neighbouring samples.
+func closestOnCurve(p vector2.Vector2, lo, hi float64, samples int, eval func(t float64) curveSample) float64 {
+	step := (hi - lo) / float64(samples)
+	distances := make([]float64, samples+1)
+	for i := range distances {
+		distances[i] = p.DistanceSquaredTo(eval(lo + float64(i)*step).point)
+	}
+	best, bestDistance := lo, math.Inf(1)
+	for i, d := range distances {
+		if (i > 0 && distances[i-1] < d) || (i < samples && distances[i+1] < d) {
+			continue
+		}
+		t := lo + float64(i)*step
+		from, to := math.Max(lo, t-step), math.Min(hi, t+step)
+		for k := 0; k < 16; k++ {
+			s := eval(t)
+			offset := s.point.Sub(p)
+			g := offset.Dot(s.d1)
+			dg := s.d1.Dot(s.d1) + offset.Dot(s.d2)
+			if dg <= 0 {
+				break
+			}
+			next := math.Min(to, math.Max(from, t-g/dg))
+			if next == t {
+				break
+			}
+			t = next
+		}
+		candidate, candidateDistance := lo+float64(i)*step, d
+		if refined := p.DistanceSquaredTo(eval(t).point); refined < candidateDistance {
+			candidate, candidateDistance = t, refined
+		}
+		if candidateDistance < bestDistance {
+			best, bestDistance = candidate, candidateDistance
+		}
+	}
+	return best
+}
+
+// derivativeRoots returns the parameters in [lo, hi] where the derivative
+// changes sign, found by bisecting between evenly spaced samples.
+func derivativeRoots(lo, hi float64, samples int, derivative func(t float64) float64) []float64 {
+	var roots []float64
+	step := (hi - lo) / float64(samples)
+	prev := derivative(lo)
+	for i := 1; i <= samples; i++ {
+		a, b := lo+float64(i-1)*step, lo+float64(i)*step
+		next := derivative(b)
+		if (prev < 0) != (next < 0) {
+			fa := prev
+			for k := 0; k < 60 && b-a > 1e-15*math.Max(1, math.Abs(a)); k++ {
+				mid := (a + b) / 2
+				if fm := derivative(mid); (fm < 0) == (fa < 0) {
+					a, fa = mid, fm
+				} else {
+					b = mid
+				}
+			}
+			roots = append(roots, (a+b)/2)
+		}
+		prev = next
+	}
+	return roots
+}
+
+// curveLocate classifies a point against the area between a curve and its
+// chord, given the distance to the curve and the curve flattened within a
+// fraction of the tolerance.
+func curveLocate(p vector2.Vector2, distance float64, outline Polygon, opts ContainOptions) location {
+	if onBoundary(distance, opts) {
+		return boundary
+	}
+	if len(outline) < 3 {
+		return outside
+	}
+	start, end := outline[0], outline[len(outline)-1]
+	if onSegment(p, end, start, opts.Tolerance) {
+		return boundary
+	}
+	if insideWinding(windingNumber(outline, p), NonZero) {
+		return inside
+	}
+	return outside
+}
+
+// drawPolyline adds the points to the current path.
+func drawPolyline(dc *gg.Context, points []vector2.Vector2) {
+	dc.NewSubPath()
+	for _, p := range points {
+		dc.LineTo(p.X, p.Y)
+	}
+}
+
+// PolygonFromPath joins a closed outline made of consecutive Line, Arc,
+// EllipticalArc, Bezier and BSpline segments, such as a glyph or an imported
+// path, into one polygon. Curves are flattened within tolerance, and points
+// shared by consecutive segments appear once.
+func PolygonFromPath(path []Shape, tolerance float64) Polygon {
+	var polygon Polygon
+	for _, s := range path {
+		var points []vector2.Vector2
+		switch s := s.(type) {
+		case Line:
+			points = []vector2.Vector2{s.Start, s.End}
+		case Arc:
+			points = s.elliptical().points(tolerance)
+		default:
+			points = Polygonize(s, tolerance)
+		}
+		for _, p := range points {
+			if len(polygon) == 0 || !polygon[len(polygon)-1].IsEqualApprox(p) {
+				polygon = append(polygon, p)
+			}
+		}
+	}
+	if len(polygon) > 1 && polygon[0].IsEqualApprox(polygon[len(polygon)-1]) {
+		polygon = polygon[:len(polygon)-1]
+	}
+	return polygon
+}
+
+func lerp(a, b vector2.Vector2, t float64) vector2.Vector2 {
+	return vector2.Vector2{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t}
+}
+
+// flattenPieces joins the flattened Bezier pieces of a curve into an open
+// polyline. A tolerance of zero selects DefaultTolerance.
+func flattenPieces(pieces [][]hpoint, tolerance float64) Polygon {
+	if len(pieces) == 0 {
+		return Polygon{}
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	points := Polygon{pieces[0][0].point()}
+	for _, piece := range pieces {
+		flattenBezier(piece, tolerance, 0, func(flat []hpoint) {
+			points = append(points, flat[len(flat)-1].point())
+		})
+	}
+	return points
+}
+
+// outlineTolerance returns the tolerance to flatten a curve with for
+// containment tests, fine enough that only points counted as on the
+// boundary can be misjudged.
+func outlineTolerance(bounds rect2.Rect2, opts ContainOptions) float64 {
+	return math.Max(opts.Tolerance/2, 1e-6*math.Max(bounds.Size.X, bounds.Size.Y))
+}
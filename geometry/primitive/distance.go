@@ -0,0 +1,93 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/geometry2d"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// DistanceSample is the signed distance field of a shape evaluated at a
+// point, with the information needed to move the point onto the outline or
+// along its normal.
+type DistanceSample struct {
+	// Distance is the signed distance to the outline, negative inside.
+	// Shapes without an inside, such as lines and curves, never give a
+	// negative distance.
+	Distance float64
+	// Closest is the point of the outline nearest to the sampled point.
+	Closest vector2.Vector2
+	// Gradient is the unit vector along which the distance grows fastest:
+	// away from the outline outside the shape and towards it inside. On the
+	// outline it is the outward normal.
+	Gradient vector2.Vector2
+}
+
+// newDistanceSample returns the sample at p given the nearest point of the
+// outline, whether p is inside, and the unit outward normal at the nearest
+// point, which is the gradient when p lies on the outline.
+func newDistanceSample(p, closest vector2.Vector2, inside bool, normal vector2.Vector2) DistanceSample {
+	offset := p.Sub(closest)
+	d := offset.Length()
+	if onOutline(p, closest) {
+		if inside {
+			d = -d
+		}
+		return DistanceSample{Distance: d, Closest: closest, Gradient: normal}
+	}
+	gradient := offset.Divf(d)
+	if inside {
+		return DistanceSample{Distance: -d, Closest: closest, Gradient: gradient.Mulf(-1)}
+	}
+	return DistanceSample{Distance: d, Closest: closest, Gradient: gradient}
+}
+
+// ringsSample returns the sample at p against the edges of the rings, where
+// fills reports which points are inside. The normal of the nearest edge is
+// only needed for points on the outline, and points to whichever side is
+// not filled.
+func ringsSample(rings []Polygon, p vector2.Vector2, fills func(vector2.Vector2) bool) DistanceSample {
+	best := math.Inf(1)
+	var closest, a, b vector2.Vector2
+	for _, ring := range rings {
+		for i := range ring {
+			segment := [2]vector2.Vector2{ring[i], ring[(i+1)%len(ring)]}
+			c := geometry2d.GetClosestPointToSegment(p, segment)
+			if d := p.DistanceSquaredTo(c); d < best {
+				best, closest, a, b = d, c, segment[0], segment[1]
+			}
+		}
+	}
+	if math.IsInf(best, 1) {
+		return DistanceSample{Distance: math.Inf(1)}
+	}
+	if !onOutline(p, closest) {
+		return newDistanceSample(p, closest, fills(p), vector2.Vector2{})
+	}
+	edge := b.Sub(a)
+	normal := vector2.Vector2{X: edge.Y, Y: -edge.X}.Normalized()
+	if fills(closest.Add(normal.Mulf(1e-6 * edge.Length()))) {
+		normal = normal.Mulf(-1)
+	}
+	return newDistanceSample(p, closest, false, normal)
+}
+
+// onOutline reports whether p is within rounding error of its nearest
+// outline point, so that the offset between them has no reliable direction.
+func onOutline(p, closest vector2.Vector2) bool {
+	return p.DistanceTo(closest) <= 1e-12*(1+math.Abs(closest.X)+math.Abs(closest.Y))
+}
+
+// curveDistanceSample returns the unsigned sample at p given the nearest
+// sample of a curve. On the curve the gradient is the normal to the left of
+// the direction of travel.
+func curveDistanceSample(p vector2.Vector2, s curveSample) DistanceSample {
+	normal := vector2.Vector2{X: -s.d1.Y, Y: s.d1.X}.Normalized()
+	return newDistanceSample(p, s.point, false, normal)
+}
+
+// ellipseNormal returns the unit outward normal at a point of an axis
+// aligned ellipse centered on the origin.
+func ellipseNormal(p vector2.Vector2, a, b float64) vector2.Vector2 {
+	return vector2.Vector2{X: p.X * b * b, Y: p.Y * a * a}.Normalized()
+}
@@ -0,0 +1,193 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
+	"github.com/fogleman/gg"
+)
+
+// Ellipse is an ellipse with radii along its own x and y axes, rotated by
+// Rotation radians about its center.
+type Ellipse struct {
+	Center   vector2.Vector2
+	Radii    vector2.Vector2
+	Rotation float64
+}
+
+func NewEllipse(centerX, centerY, radiusX, radiusY, rotation float64) Ellipse {
+	return Ellipse{
+		Center:   vector2.Vector2{X: centerX, Y: centerY},
+		Radii:    vector2.Vector2{X: radiusX, Y: radiusY},
+		Rotation: rotation,
+	}
+}
+
+func (e Ellipse) Translate(offsetX, offsetY float64) Shape {
+	e.Center = e.Center.Add(vector2.Vector2{X: offsetX, Y: offsetY})
+	return e
+}
+
+func (e Ellipse) Scale(factor float64) Shape {
+	e.Center = e.Center.Mulf(factor)
+	e.Radii = e.Radii.Mulf(factor)
+	return e
+}
+
+// Transform maps the ellipse through an affine transform. The image of an
+// ellipse is always an ellipse; its radii and rotation come from the
+// eigen decomposition of the transformed axes.
+func (e Ellipse) Transform(m algebra.Affine) Shape {
+	sin, cos := math.Sincos(e.Rotation)
+	u := m.ApplyVector(vector2.Vector2{X: cos * e.Radii.X, Y: sin * e.Radii.X})
+	v := m.ApplyVector(vector2.Vector2{X: -sin * e.Radii.Y, Y: cos * e.Radii.Y})
+	return ellipseFromAxes(m.Apply(e.Center), u, v)
+}
+
+// ellipseFromAxes returns the ellipse traced by center + u cos t + v sin t
+// for any pair of conjugate semi-axes u and v.
+func ellipseFromAxes(center, u, v vector2.Vector2) Ellipse {
+	sxx := u.X*u.X + v.X*v.X
+	syy := u.Y*u.Y + v.Y*v.Y
+	sxy := u.X*u.Y + v.X*v.Y
+	mean := (sxx + syy) / 2
+	spread := math.Hypot((sxx-syy)/2, sxy)
+	return Ellipse{
+		Center:   center,
+		Radii:    vector2.Vector2{X: math.Sqrt(mean + spread), Y: math.Sqrt(math.Max(mean-spread, 0))},
+		Rotation: math.Atan2(2*sxy, sxx-syy) / 2,
+	}
+}
+
+// GetBoundingBox returns the exact bounds of the rotated ellipse.
+func (e Ellipse) GetBoundingBox() rect2.Rect2 {
+	sin, cos := math.Sincos(e.Rotation)
+	hx := math.Hypot(e.Radii.X*cos, e.Radii.Y*sin)
+	hy := math.Hypot(e.Radii.X*sin, e.Radii.Y*cos)
+	return rect2.Rect2{
+		Position: vector2.Vector2{X: e.Center.X - hx, Y: e.Center.Y - hy},
+		Size:     vector2.Vector2{X: hx * 2, Y: hy * 2},
+	}
+}
+
+// PointAt returns the point at parameter t, measured in the ellipse's own
+// frame before rotation.
+func (e Ellipse) PointAt(t float64) vector2.Vector2 {
+	local := vector2.Vector2{X: e.Radii.X * math.Cos(t), Y: e.Radii.Y * math.Sin(t)}
+	return rotate(local, e.Rotation).Add(e.Center)
+}
+
+// polygon samples the outline so that no chord deviates from it by more than
+// tolerance, using the tightest curvature radius of the ellipse.
+func (e Ellipse) polygon(tolerance float64) Polygon {
+	major := math.Max(e.Radii.X, e.Radii.Y)
+	minor := math.Min(e.Radii.X, e.Radii.Y)
+	if major <= 0 {
+		return Polygon{e.Center}
+	}
+	curvature := math.Max(minor*minor/major, 1e-12)
+	step := chordInterval(curvature, tolerance) / major
+	steps := int(math.Max(8, math.Ceil(2*math.Pi/step)))
+	points := make(Polygon, steps)
+	for i := range points {
+		points[i] = e.PointAt(2 * math.Pi * float64(i) / float64(steps))
+	}
+	return points
+}
+
+func (e Ellipse) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	e.path(dc)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (e Ellipse) DrawDashed(dc *gg.Context, color [4]float64, lwidth, dashLength, dashInterval float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	e.path(dc)
+	dc.SetDash(dashLength, dashInterval)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (e Ellipse) DrawFilled(dc *gg.Context, color [4]float64) {
+	dc.Push()
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	e.path(dc)
+	dc.Fill()
+	dc.Pop()
+}
+
+func (e Ellipse) path(dc *gg.Context) {
+	dc.Translate(e.Center.X, e.Center.Y)
+	dc.Rotate(e.Rotation)
+	dc.DrawEllipse(0, 0, e.Radii.X, e.Radii.Y)
+}
+
+// SignedDistance returns the exact distance to the ellipse outline, negative
+// inside.
+func (e Ellipse) SignedDistance(p vector2.Vector2) float64 {
+	local := rotate(p.Sub(e.Center), -e.Rotation)
+	closest := ellipseClosestPoint(local, e.Radii.X, e.Radii.Y)
+	d := local.DistanceTo(closest)
+	if e.hasInside(local) {
+		return -d
+	}
+	return d
+}
+
+// SampleDistance returns the signed distance with the nearest point of the
+// ellipse outline.
+func (e Ellipse) SampleDistance(p vector2.Vector2) DistanceSample {
+	local := rotate(p.Sub(e.Center), -e.Rotation)
+	closest := ellipseClosestPoint(local, e.Radii.X, e.Radii.Y)
+	normal := ellipseNormal(closest, e.Radii.X, e.Radii.Y)
+	if normal.X == 0 && normal.Y == 0 {
+		normal = local.Sub(closest).Normalized()
+	}
+	return newDistanceSample(p, e.Center.Add(rotate(closest, e.Rotation)), e.hasInside(local), rotate(normal, e.Rotation))
+}
+
+// hasInside reports whether a point in the ellipse's own frame lies strictly
+// inside it. Degenerate ellipses have no inside.
+func (e Ellipse) hasInside(local vector2.Vector2) bool {
+	if e.Radii.X <= 0 || e.Radii.Y <= 0 {
+		return false
+	}
+	return (local.X*local.X)/(e.Radii.X*e.Radii.X)+(local.Y*local.Y)/(e.Radii.Y*e.Radii.Y) < 1
+}
+
+// ellipseClosestPoint returns the point of the axis aligned ellipse with
+// radii a and b nearest to p. The search works in the first quadrant and
+// refines the parameter by repeatedly projecting onto the osculating circle,
+// which converges to full precision in a handful of iterations.
+func ellipseClosestPoint(p vector2.Vector2, a, b float64) vector2.Vector2 {
+	px, py := math.Abs(p.X), math.Abs(p.Y)
+	if a <= 0 || b <= 0 {
+		// Degenerate ellipses collapse to a segment along the other axis.
+		return vector2.Vector2{X: math.Copysign(math.Min(px, math.Max(a, 0)), p.X), Y: math.Copysign(math.Min(py, math.Max(b, 0)), p.Y)}
+	}
+	tx, ty := math.Sqrt2/2, math.Sqrt2/2
+	for i := 0; i < 8; i++ {
+		x, y := a*tx, b*ty
+		ex := (a*a - b*b) * tx * tx * tx / a
+		ey := (b*b - a*a) * ty * ty * ty / b
+		r := math.Hypot(x-ex, y-ey)
+		q := math.Hypot(px-ex, py-ey)
+		if q == 0 {
+			break
+		}
+		tx = math.Min(1, math.Max(0, ((px-ex)*r/q+ex)/a))
+		ty = math.Min(1, math.Max(0, ((py-ey)*r/q+ey)/b))
+		t := math.Hypot(tx, ty)
+		tx /= t
+		ty /= t
+	}
+	return vector2.Vector2{X: math.Copysign(a*tx, p.X), Y: math.Copysign(b*ty, p.Y)}
+}
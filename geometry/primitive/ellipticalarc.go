@@ -0,0 +1,332 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
+	"github.com/fogleman/gg"
+)
+
+// EllipticalArc is part of an ellipse's outline. AngleStart and AngleEnd are
+// parameters of Ellipse.PointAt, which match polar angles only when the
+// ellipse is a circle.
+type EllipticalArc struct {
+	Ellipse              Ellipse
+	AngleStart, AngleEnd float64
+}
+
+func NewEllipticalArc(centerX, centerY, radiusX, radiusY, rotation, angleStart, angleEnd float64) EllipticalArc {
+	return EllipticalArc{
+		Ellipse:    NewEllipse(centerX, centerY, radiusX, radiusY, rotation),
+		AngleStart: angleStart,
+		AngleEnd:   angleEnd,
+	}
+}
+
+func (a EllipticalArc) Translate(offsetX, offsetY float64) Shape {
+	a.Ellipse = a.Ellipse.Translate(offsetX, offsetY).(Ellipse)
+	return a
+}
+
+func (a EllipticalArc) Scale(factor float64) Shape {
+	a.Ellipse = a.Ellipse.Scale(factor).(Ellipse)
+	return a
+}
+
+// Transform maps the arc through an affine transform. The ellipse is
+// re-derived from its mapped axes, so the parameters are shifted to match,
+// and a transform that reverses the direction of travel negates the sweep.
+func (a EllipticalArc) Transform(m algebra.Affine) Shape {
+	e := a.Ellipse.Transform(m).(Ellipse)
+	start := e.parameterOf(m.Apply(a.StartPoint()))
+	quarter := e.parameterOf(m.Apply(a.PointAt(a.AngleStart + math.Pi/2)))
+	sweep := a.Sweep()
+	if math.Sin(quarter-start) < 0 {
+		sweep = -sweep
+	}
+	return EllipticalArc{Ellipse: e, AngleStart: start, AngleEnd: start + sweep}
+}
+
+// GetBoundingBox returns the exact bounds of the arc's curve, including any
+// extremes of the ellipse crossed by the sweep.
+func (a EllipticalArc) GetBoundingBox() rect2.Rect2 {
+	e := a.Ellipse
+	rect := rect2.Rect2{Position: a.StartPoint()}.Expand(a.EndPoint())
+	sin, cos := math.Sincos(e.Rotation)
+	lo, hi := math.Min(a.AngleStart, a.AngleEnd), math.Max(a.AngleStart, a.AngleEnd)
+	// The x and y extremes, each repeating every half turn.
+	for _, t0 := range []float64{math.Atan2(-e.Radii.Y*sin, e.Radii.X*cos), math.Atan2(e.Radii.Y*cos, e.Radii.X*sin)} {
+		for k := math.Ceil((lo - t0) / math.Pi); t0+k*math.Pi <= hi; k++ {
+			rect = rect.Expand(a.PointAt(t0 + k*math.Pi))
+		}
+	}
+	return rect
+}
+
+func (a EllipticalArc) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	a.path(dc)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (a EllipticalArc) DrawDashed(dc *gg.Context, color [4]float64, lwidth, dashLength, dashInterval float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	a.path(dc)
+	dc.SetDash(dashLength, dashInterval)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (a EllipticalArc) DrawFilled(dc *gg.Context, color [4]float64) {
+	dc.Push()
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	a.path(dc)
+	dc.Fill()
+	dc.Pop()
+}
+
+func (a EllipticalArc) path(dc *gg.Context) {
+	e := a.Ellipse
+	dc.Translate(e.Center.X, e.Center.Y)
+	dc.Rotate(e.Rotation)
+	dc.DrawEllipticalArc(0, 0, e.Radii.X, e.Radii.Y, a.AngleStart, a.AngleEnd)
+}
+
+// SignedDistance returns the distance to the arc's curve. Like a line, an
+// arc has no inside, so the result is never negative.
+func (a EllipticalArc) SignedDistance(p vector2.Vector2) float64 {
+	return p.DistanceTo(a.PointAt(a.closestParameter(p)))
+}
+
+// SampleDistance returns the distance to the arc's curve with its nearest
+// point. On the curve the gradient is the ellipse's outward normal.
+func (a EllipticalArc) SampleDistance(p vector2.Vector2) DistanceSample {
+	e := a.Ellipse
+	t := a.closestParameter(p)
+	sin, cos := math.Sincos(t)
+	normal := ellipseNormal(vector2.Vector2{X: e.Radii.X * cos, Y: e.Radii.Y * sin}, e.Radii.X, e.Radii.Y)
+	return newDistanceSample(p, a.PointAt(t), false, rotate(normal, e.Rotation))
+}
+
+// closestParameter returns the parameter of the point of the arc nearest to
+// p. The nearest point of the whole ellipse is used when the arc covers it;
+// otherwise the nearest point is an end point or the other local minimum of
+// the distance, which is found by refining the best of a set of samples
+// with Newton's method.
+func (a EllipticalArc) closestParameter(p vector2.Vector2) float64 {
+	e := a.Ellipse
+	local := rotate(p.Sub(e.Center), -e.Rotation)
+	closest := ellipseClosestPoint(local, e.Radii.X, e.Radii.Y)
+	if t := e.parameterOf(rotate(closest, e.Rotation).Add(e.Center)); a.withinSweep(t) {
+		return t
+	}
+
+	best, bestDistance := a.AngleStart, p.DistanceTo(a.StartPoint())
+	if d := p.DistanceTo(a.EndPoint()); d < bestDistance {
+		best, bestDistance = a.AngleEnd, d
+	}
+	const samples = 32
+	step := a.Sweep() / samples
+	seed := 0
+	seedDistance := math.Inf(1)
+	for i := 0; i <= samples; i++ {
+		if d := p.DistanceTo(a.PointAt(a.AngleStart + float64(i)*step)); d < seedDistance {
+			seed, seedDistance = i, d
+		}
+	}
+	lo := a.AngleStart + float64(max(seed-1, 0))*step
+	hi := a.AngleStart + float64(min(seed+1, samples))*step
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	rx, ry := e.Radii.X, e.Radii.Y
+	t := a.AngleStart + float64(seed)*step
+	for i := 0; i < 16; i++ {
+		sin, cos := math.Sincos(t)
+		dx, dy := rx*cos-local.X, ry*sin-local.Y
+		// The distance is smallest where the offset is normal to the curve.
+		g := -dx*rx*sin + dy*ry*cos
+		dg := rx*rx*sin*sin + ry*ry*cos*cos - dx*rx*cos - dy*ry*sin
+		if dg <= 0 {
+			break
+		}
+		t = math.Min(hi, math.Max(lo, t-g/dg))
+	}
+	if d := p.DistanceTo(a.PointAt(t)); d < bestDistance {
+		best = t
+	}
+	return best
+}
+
+// Contains tests the point against the sector or segment of the arc chosen
+// by opts.Arc, as for an Arc.
+func (a EllipticalArc) Contains(p vector2.Vector2, opts ContainOptions) bool {
+	return a.locate(p, opts).contained(opts)
+}
+
+func (a EllipticalArc) locate(p vector2.Vector2, opts ContainOptions) location {
+	e := a.Ellipse
+	if onBoundary(p.DistanceTo(a.PointAt(a.closestParameter(p))), opts) {
+		return boundary
+	}
+	local := rotate(p.Sub(e.Center), -e.Rotation)
+	within := e.Radii.X > 0 && e.Radii.Y > 0 &&
+		(local.X*local.X)/(e.Radii.X*e.Radii.X)+(local.Y*local.Y)/(e.Radii.Y*e.Radii.Y) < 1
+	if math.Abs(a.Sweep()) >= 2*math.Pi {
+		if within {
+			return inside
+		}
+		return outside
+	}
+	start, end := a.StartPoint(), a.EndPoint()
+	if opts.Arc == ArcSegment {
+		if onSegment(p, start, end, opts.Tolerance) {
+			return boundary
+		}
+		mid := a.PointAt(a.AngleStart + a.Sweep()/2)
+		chord := end.Sub(start)
+		side := chord.Cross(p.Sub(start)) * chord.Cross(mid.Sub(start))
+		if within && side > 0 {
+			return inside
+		}
+		return outside
+	}
+	if onSegment(p, e.Center, start, opts.Tolerance) || onSegment(p, e.Center, end, opts.Tolerance) {
+		return boundary
+	}
+	if within && a.withinSweep(e.parameterOf(p)) {
+		return inside
+	}
+	return outside
+}
+
+// withinSweep reports whether the parameter t falls inside the range swept
+// by the arc.
+func (a EllipticalArc) withinSweep(t float64) bool {
+	sweep := a.Sweep()
+	if math.Abs(sweep) >= 2*math.Pi {
+		return true
+	}
+	rel := t - a.AngleStart
+	if sweep < 0 {
+		rel, sweep = -rel, -sweep
+	}
+	rel = math.Mod(rel, 2*math.Pi)
+	if rel < 0 {
+		rel += 2 * math.Pi
+	}
+	return rel <= sweep
+}
+
+// Sweep returns the signed parameter range from AngleStart to AngleEnd.
+func (a EllipticalArc) Sweep() float64 {
+	return a.AngleEnd - a.AngleStart
+}
+
+// PointAt returns the point on the arc's ellipse at parameter t.
+func (a EllipticalArc) PointAt(t float64) vector2.Vector2 {
+	return a.Ellipse.PointAt(t)
+}
+
+func (a EllipticalArc) StartPoint() vector2.Vector2 {
+	return a.PointAt(a.AngleStart)
+}
+
+func (a EllipticalArc) EndPoint() vector2.Vector2 {
+	return a.PointAt(a.AngleEnd)
+}
+
+// Length returns the length of the arc's curve.
+func (a EllipticalArc) Length() float64 {
+	lengths := a.lengths(a.AngleStart, a.Sweep())
+	return lengths[len(lengths)-1]
+}
+
+// Discretize samples the arc like Arc.Discretize: the span runs from
+// AngleStart to AngleEnd along the signed Sweep, and is cut into an odd
+// number of points, at least minSteps, with no more than maxInterval of arc
+// length between them. The points are spaced evenly by arc length rather
+// than by parameter.
+func (a EllipticalArc) Discretize(maxInterval float64, minSteps int) []vector2.Vector2 {
+	totalAngle := a.Sweep()
+	lengths := a.lengths(a.AngleStart, totalAngle)
+	arcLength := lengths[len(lengths)-1]
+
+	numSteps := max(int(math.Ceil(arcLength/maxInterval)), minSteps)
+	if numSteps%2 == 0 {
+		numSteps++
+	}
+
+	points := make([]vector2.Vector2, 0, numSteps)
+	segment := 0
+	spacing := totalAngle / float64(len(lengths)-1)
+	for i := 0; i < numSteps; i++ {
+		target := arcLength * float64(i) / float64(max(numSteps-1, 1))
+		for segment < len(lengths)-2 && lengths[segment+1] < target {
+			segment++
+		}
+		t := a.AngleStart + float64(segment)*spacing
+		if span := lengths[segment+1] - lengths[segment]; span > 0 {
+			t += spacing * (target - lengths[segment]) / span
+		}
+		points = append(points, a.PointAt(t))
+	}
+	return points
+}
+
+// lengths returns the cumulative arc length at evenly spaced parameters
+// from start across sweep, integrating the speed with Simpson's rule.
+func (a EllipticalArc) lengths(start, sweep float64) []float64 {
+	rx, ry := a.Ellipse.Radii.X, a.Ellipse.Radii.Y
+	speed := func(t float64) float64 {
+		sin, cos := math.Sincos(t)
+		return math.Hypot(rx*sin, ry*cos)
+	}
+	n := max(int(math.Ceil(math.Abs(sweep)/(math.Pi/128))), 1)
+	h := sweep / float64(n)
+	lengths := make([]float64, n+1)
+	for i := 0; i < n; i++ {
+		t := start + float64(i)*h
+		lengths[i+1] = lengths[i] + math.Abs(h)/6*(speed(t)+4*speed(t+h/2)+speed(t+h))
+	}
+	return lengths
+}
+
+// points samples the arc along its signed sweep so that no chord deviates
+// from the curve by more than tolerance.
+func (a EllipticalArc) points(tolerance float64) []vector2.Vector2 {
+	major := math.Max(a.Ellipse.Radii.X, a.Ellipse.Radii.Y)
+	minor := math.Min(a.Ellipse.Radii.X, a.Ellipse.Radii.Y)
+	steps := 1
+	if major > 0 {
+		curvature := math.Max(minor*minor/major, 1e-12)
+		step := chordInterval(curvature, tolerance) / major
+		steps = int(math.Max(1, math.Ceil(math.Abs(a.Sweep())/step)))
+	}
+	points := make([]vector2.Vector2, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		points = append(points, a.PointAt(a.AngleStart+a.Sweep()*float64(i)/float64(steps)))
+	}
+	return points
+}
+
+// elliptical returns the arc as an EllipticalArc on a circular ellipse, whose
+// parameters are the arc's angles.
+func (a Arc) elliptical() EllipticalArc {
+	e := Ellipse{Center: a.Circle.Center, Radii: vector2.Vector2{X: a.Circle.Radius, Y: a.Circle.Radius}}
+	return EllipticalArc{Ellipse: e, AngleStart: a.AngleStart, AngleEnd: a.AngleEnd}
+}
+
+// parameterOf returns the parameter of the ellipse whose point lies in the
+// direction of p from the center.
+func (e Ellipse) parameterOf(p vector2.Vector2) float64 {
+	local := rotate(p.Sub(e.Center), -e.Rotation)
+	return math.Atan2(local.Y*e.Radii.X, local.X*e.Radii.Y)
+}
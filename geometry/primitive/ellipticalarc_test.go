@@ -0,0 +1,42 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/anaxarchus/MathEngine/algebra"
+)
+
+func TestEllipticalArcDiscretizeFollowsSweep(t *testing.T) {
+	for _, sweep := range []float64{math.Pi / 2, -math.Pi / 2} {
+		arc := NewEllipticalArc(0, 0, 20, 10, 0, 0, sweep)
+		points := arc.Discretize(0.5, 3)
+		if !points[0].IsEqualApprox(arc.StartPoint()) || !points[len(points)-1].IsEqualApprox(arc.EndPoint()) {
+			t.Errorf("sweep %g: ends = %v, %v, want %v, %v", sweep, points[0], points[len(points)-1], arc.StartPoint(), arc.EndPoint())
+		}
+		for _, p := range points {
+			if p.X < -1e-9 || p.Y*sweep < -1e-9 {
+				t.Fatalf("sweep %g: point %v lies outside the arc's quarter", sweep, p)
+			}
+		}
+		length := 0.0
+		for i := 1; i < len(points); i++ {
+			length += points[i].DistanceTo(points[i-1])
+		}
+		if math.Abs(length-arc.Length()) > 1e-2 {
+			t.Errorf("sweep %g: polyline length %g, want about %g", sweep, length, arc.Length())
+		}
+	}
+}
+
+func TestArcTransformNonSimilarKeepsSide(t *testing.T) {
+	arc := NewArc(0, 0, 10, 0, -math.Pi/2).Transform(algebra.Scaling(2, 1))
+	e, ok := arc.(EllipticalArc)
+	if !ok {
+		t.Fatalf("got %T, want EllipticalArc", arc)
+	}
+	points := e.Discretize(0.5, 3)
+	if mid := points[len(points)/2]; mid.X <= 0 || mid.Y >= 0 {
+		t.Errorf("midpoint %v lies outside the clockwise quarter", mid)
+	}
+}
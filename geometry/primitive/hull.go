@@ -0,0 +1,112 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/hull"
+)
+
+// ConvexHull returns the convex hull of the shapes as a counter-clockwise
+// polygon. Curved outlines are replaced by circumscribed polygons whose edges
+// are tangent to the true curve, so the hull always contains the shapes and
+// lies within tolerance of their exact hull. A tolerance of zero or less uses
+// DefaultTolerance.
+func ConvexHull(tolerance float64, shapes ...Shape) Polygon {
+	var points []vector2.Vector2
+	for _, s := range shapes {
+		points = append(points, hullPoints(s, tolerance)...)
+	}
+	return Polygon(hull.MonotoneChain(points))
+}
+
+// ConvexHull returns the convex hull of the polygon's vertices.
+func (p Polygon) ConvexHull() Polygon {
+	return Polygon(hull.MonotoneChain(p))
+}
+
+// hullPoints returns a set of points whose hull encloses the shape.
+func hullPoints(s Shape, tolerance float64) []vector2.Vector2 {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	switch s := s.(type) {
+	case Polygon:
+		return s
+	case Region:
+		var points []vector2.Vector2
+		for _, ring := range s.Rings {
+			points = append(points, ring...)
+		}
+		return points
+	case Line:
+		return []vector2.Vector2{s.Start, s.End}
+	case Rectangle:
+		return Polygonize(s, 0)
+	case Circle:
+		return circumscribed(s.Center, s.Radius, 0, 2*math.Pi, tolerance)
+	case Arc:
+		points := circumscribed(s.Circle.Center, s.Circle.Radius, s.AngleStart, s.Sweep(), tolerance)
+		return append(points, s.StartPoint(), s.EndPoint())
+	case Ellipse:
+		return ellipseHullPoints(s, 0, 2*math.Pi, tolerance)
+	case EllipticalArc:
+		points := ellipseHullPoints(s.Ellipse, s.AngleStart, s.Sweep(), tolerance)
+		return append(points, s.StartPoint(), s.EndPoint())
+	case curve:
+		// Each flat piece lies inside the hull of its control points, which
+		// all stand within tolerance of its chord.
+		var points []vector2.Vector2
+		for _, piece := range s.pieces() {
+			flattenBezier(piece, tolerance, 0, func(flat []hpoint) {
+				for _, c := range flat {
+					points = append(points, c.point())
+				}
+			})
+		}
+		return points
+	}
+	return Polygonize(s, tolerance)
+}
+
+// ellipseHullPoints returns the corners of the tangent polygon around part
+// of an ellipse. The unit circle's circumscribed polygon maps onto one for
+// the ellipse, since affine maps keep tangent lines tangent.
+func ellipseHullPoints(e Ellipse, start, sweep, tolerance float64) []vector2.Vector2 {
+	major := math.Max(math.Abs(e.Radii.X), math.Abs(e.Radii.Y))
+	if major == 0 {
+		return []vector2.Vector2{e.Center}
+	}
+	m := algebra.Translation(e.Center.X, e.Center.Y).
+		Mul(algebra.Rotation(e.Rotation)).
+		Mul(algebra.Scaling(e.Radii.X, e.Radii.Y))
+	points := circumscribed(vector2.Vector2{}, 1, start, sweep, tolerance/major)
+	for i, p := range points {
+		points[i] = m.Apply(p)
+	}
+	return points
+}
+
+// circumscribed returns the corners of the tangent polygon around a circular
+// arc of the given sweep. Corners stand at most tolerance off the circle,
+// which must be positive.
+func circumscribed(center vector2.Vector2, radius, start, sweep, tolerance float64) []vector2.Vector2 {
+	if radius <= 0 {
+		return []vector2.Vector2{center}
+	}
+	step := math.Min(math.Pi/2, 2*math.Acos(radius/(radius+tolerance)))
+	steps := int(math.Max(1, math.Ceil(math.Abs(sweep)/step)))
+	step = sweep / float64(steps)
+	// Tangents at consecutive samples meet on the bisector at this radius.
+	corner := radius / math.Cos(step/2)
+	points := make([]vector2.Vector2, 0, steps)
+	for i := 0; i < steps; i++ {
+		angle := start + (float64(i)+0.5)*step
+		points = append(points, vector2.Vector2{
+			X: center.X + corner*math.Cos(angle),
+			Y: center.Y + corner*math.Sin(angle),
+		})
+	}
+	return points
+}
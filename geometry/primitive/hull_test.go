@@ -0,0 +1,27 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvexHullDefaultTolerance(t *testing.T) {
+	shapes := []Shape{
+		NewCircle(0, 0, 10),
+		NewArc(0, 0, 10, 0, -3*math.Pi/2),
+		NewEllipse(0, 0, 10, 4, math.Pi/5),
+	}
+	for _, tolerance := range []float64{0, -1} {
+		for _, s := range shapes {
+			h := ConvexHull(tolerance, s)
+			if len(h) <= 4 {
+				t.Fatalf("%T with tolerance %g: hull has %d corners", s, tolerance, len(h))
+			}
+			for _, v := range h {
+				if d := s.SignedDistance(v); d > DefaultTolerance+1e-9 {
+					t.Errorf("%T with tolerance %g: corner %v stands %g off the outline", s, tolerance, v, d)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package primitive
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/rtree"
+)
+
+// ShapeIndex is a spatial index over shapes keyed on their bounding boxes.
+// Shapes are referred to by the id Insert returns.
+type ShapeIndex struct {
+	tree   *rtree.Tree
+	shapes map[int]Shape
+	bounds map[int]rect2.Rect2
+	next   int
+}
+
+// NewShapeIndex returns an index holding the shapes, with ids matching their
+// positions in the argument list.
+func NewShapeIndex(shapes ...Shape) *ShapeIndex {
+	si := &ShapeIndex{
+		tree:   rtree.New(),
+		shapes: map[int]Shape{},
+		bounds: map[int]rect2.Rect2{},
+	}
+	for _, s := range shapes {
+		si.Insert(s)
+	}
+	return si
+}
+
+// Insert adds a shape and returns its id.
+func (si *ShapeIndex) Insert(s Shape) int {
+	id := si.next
+	si.next++
+	si.shapes[id] = s
+	si.bounds[id] = s.GetBoundingBox()
+	si.tree.Insert(id, si.bounds[id])
+	return id
+}
+
+// Remove deletes the shape with the given id and reports whether it was
+// present.
+func (si *ShapeIndex) Remove(id int) bool {
+	bounds, ok := si.bounds[id]
+	if !ok {
+		return false
+	}
+	si.tree.Remove(id, bounds)
+	delete(si.shapes, id)
+	delete(si.bounds, id)
+	return true
+}
+
+// Shape returns the shape with the given id.
+func (si *ShapeIndex) Shape(id int) (Shape, bool) {
+	s, ok := si.shapes[id]
+	return s, ok
+}
+
+// Len returns the number of shapes in the index.
+func (si *ShapeIndex) Len() int {
+	return len(si.shapes)
+}
+
+// Query returns the sorted ids of the shapes whose bounding boxes touch area.
+func (si *ShapeIndex) Query(area rect2.Rect2) []int {
+	ids := si.tree.Search(area)
+	sort.Ints(ids)
+	return ids
+}
+
+// Nearest returns the id of the shape closest to p and its distance, which
+// is zero inside closed shapes. The id is -1 when the index is empty.
+func (si *ShapeIndex) Nearest(p vector2.Vector2) (int, float64) {
+	found := si.tree.Nearest(p, 1, si.distanceFunc(p))
+	if len(found) == 0 {
+		return -1, math.Inf(1)
+	}
+	return found[0].ID, found[0].Distance
+}
+
+// KNearest returns the ids of the k shapes closest to p, nearest first.
+func (si *ShapeIndex) KNearest(p vector2.Vector2, k int) []int {
+	found := si.tree.Nearest(p, k, si.distanceFunc(p))
+	ids := make([]int, len(found))
+	for i, n := range found {
+		ids[i] = n.ID
+	}
+	return ids
+}
+
+// Hit returns the sorted ids of the shapes that contain p.
+func (si *ShapeIndex) Hit(p vector2.Vector2, opts ContainOptions) []int {
+	area := rect2.Rect2{Position: p}.Grow(opts.Tolerance)
+	var ids []int
+	for _, id := range si.tree.Search(area) {
+		if si.shapes[id].Contains(p, opts) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// UnionDistance returns the smallest signed distance of any shape, like
+// BooleanGroup.UnionDistance, but only evaluates the shapes whose bounding
+// boxes are near enough to matter.
+func (si *ShapeIndex) UnionDistance(p vector2.Vector2) float64 {
+	found := si.tree.Nearest(p, 1, func(id int) float64 {
+		return si.shapes[id].SignedDistance(p)
+	})
+	if len(found) == 0 {
+		return math.Inf(1)
+	}
+	return found[0].Distance
+}
+
+// DifferenceDistance returns the negated UnionDistance, like
+// BooleanGroup.DifferenceDistance.
+func (si *ShapeIndex) DifferenceDistance(p vector2.Vector2) float64 {
+	return -si.UnionDistance(p)
+}
+
+func (si *ShapeIndex) distanceFunc(p vector2.Vector2) func(int) float64 {
+	return func(id int) float64 {
+		return distanceTo(si.shapes[id], p)
+	}
+}
+
+// distanceTo returns the distance from p to a shape: to the filled area of
+// closed shapes, so zero inside them, and to the curve of lines, arcs and
+// curves.
+func distanceTo(s Shape, p vector2.Vector2) float64 {
+	return math.Max(0, s.SignedDistance(p))
+}
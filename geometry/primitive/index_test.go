@@ -0,0 +1,99 @@
+package primitive
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func randomShapes(r *rand.Rand, n int) []Shape {
+	shapes := make([]Shape, n)
+	for i := range shapes {
+		x, y := r.Float64()*100, r.Float64()*100
+		switch i % 3 {
+		case 0:
+			shapes[i] = NewCircle(x, y, 0.5+r.Float64()*3)
+		case 1:
+			shapes[i] = NewLine(x, y, x+r.Float64()*6-3, y+r.Float64()*6-3)
+		default:
+			shapes[i] = square(x, y, 0.5+r.Float64()*3)
+		}
+	}
+	return shapes
+}
+
+func TestShapeIndexMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	shapes := randomShapes(r, 300)
+	si := NewShapeIndex(shapes...)
+	for id := 0; id < len(shapes); id += 3 {
+		si.Remove(id)
+	}
+	live := func(id int) bool { return id%3 != 0 }
+
+	for q := 0; q < 200; q++ {
+		p := vector2.Vector2{X: r.Float64()*110 - 5, Y: r.Float64()*110 - 5}
+
+		best, bestDistance := -1, math.Inf(1)
+		union := math.Inf(1)
+		var hits []int
+		for id, s := range shapes {
+			if !live(id) {
+				continue
+			}
+			if d := distanceTo(s, p); d < bestDistance {
+				best, bestDistance = id, d
+			}
+			union = math.Min(union, s.SignedDistance(p))
+			if s.Contains(p, ContainOptions{}) {
+				hits = append(hits, id)
+			}
+		}
+
+		id, distance := si.Nearest(p)
+		if math.Abs(distance-bestDistance) > 1e-12 || math.Abs(distanceTo(shapes[id], p)-distance) > 1e-12 {
+			t.Fatalf("Nearest(%v) = %d at %g, want %d at %g", p, id, distance, best, bestDistance)
+		}
+		if got := si.UnionDistance(p); math.Abs(got-union) > 1e-12 {
+			t.Fatalf("UnionDistance(%v) = %g, want %g", p, got, union)
+		}
+		if got := si.Hit(p, ContainOptions{}); !equalInts(got, hits) {
+			t.Fatalf("Hit(%v) = %v, want %v", p, got, hits)
+		}
+
+		area := rect2.Rect2{Position: p, Size: vector2.Vector2{X: 10, Y: 5}}
+		var within []int
+		for id, s := range shapes {
+			if live(id) && touchesBox(s.GetBoundingBox(), area) {
+				within = append(within, id)
+			}
+		}
+		if got := si.Query(area); !equalInts(got, within) {
+			t.Fatalf("Query(%v) = %v, want %v", area, got, within)
+		}
+	}
+
+	if id, _ := NewShapeIndex().Nearest(vector2.Vector2{}); id != -1 {
+		t.Errorf("Nearest in an empty index = %d, want -1", id)
+	}
+}
+
+func touchesBox(a, b rect2.Rect2) bool {
+	return a.Position.X <= b.Position.X+b.Size.X && b.Position.X <= a.Position.X+a.Size.X &&
+		a.Position.Y <= b.Position.Y+b.Size.Y && b.Position.Y <= a.Position.Y+a.Size.Y
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
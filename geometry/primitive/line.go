@@ -0,0 +1,81 @@
+package primitive
+
+import (
+	"github.com/Anaxarchus/zero-gdscript/pkg/geometry2d"
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/fogleman/gg"
+)
+
+type Line struct {
+	Start, End vector2.Vector2
+}
+
+func NewLine(startX, startY, endX, endY float64) Line {
+	return Line{
+		Start: vector2.Vector2{X: startX, Y: startY},
+		End:   vector2.Vector2{X: endX, Y: endY},
+	}
+}
+
+func (l Line) Translate(offsetX, offsetY float64) Shape {
+	offset := vector2.Vector2{X: offsetX, Y: offsetY}
+	l.Start = l.Start.Add(offset)
+	l.End = l.End.Add(offset)
+	return l
+}
+
+func (l Line) Scale(factor float64) Shape {
+	l.Start = l.Start.Mulf(factor)
+	l.End = l.End.Mulf(factor)
+	return l
+}
+
+func (l Line) GetBoundingBox() rect2.Rect2 {
+	return rect2.Rect2{Position: l.Start}.Expand(l.End)
+}
+
+func (l Line) Length() float64 {
+	return l.Start.DistanceTo(l.End)
+}
+
+func (l Line) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	dc.DrawLine(l.Start.X, l.Start.Y, l.End.X, l.End.Y)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (l Line) DrawDashed(dc *gg.Context, color [4]float64, lwidth, dashLength, dashInterval float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	dc.DrawLine(l.Start.X, l.Start.Y, l.End.X, l.End.Y)
+	dc.SetDash(dashLength, dashInterval)
+	dc.Stroke()
+	dc.Pop()
+}
+
+// DrawFilled strokes the line with a one unit width, since a line has no
+// interior to fill.
+func (l Line) DrawFilled(dc *gg.Context, color [4]float64) {
+	l.Draw(dc, color, 1.0)
+}
+
+// SignedDistance returns the distance to the segment. A line has no inside,
+// so the result is never negative.
+func (l Line) SignedDistance(p vector2.Vector2) float64 {
+	return geometry2d.GetDistanceToSegment(p, [2]vector2.Vector2{l.Start, l.End})
+}
+
+// SampleDistance returns the distance to the segment with its nearest point.
+// On the segment the gradient is the normal to the left of the direction
+// from Start to End.
+func (l Line) SampleDistance(p vector2.Vector2) DistanceSample {
+	closest := geometry2d.GetClosestPointToSegment(p, [2]vector2.Vector2{l.Start, l.End})
+	direction := l.End.Sub(l.Start)
+	normal := vector2.Vector2{X: -direction.Y, Y: direction.X}.Normalized()
+	return newDistanceSample(p, closest, false, normal)
+}
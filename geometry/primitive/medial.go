@@ -0,0 +1,24 @@
+package primitive
+
+import (
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/medial"
+)
+
+// MedialAxis returns the medial axis of a simple polygon. Every node carries
+// its distance to the boundary, which is the depth of a V-bit with a 90°
+// included angle cutting there.
+func (p Polygon) MedialAxis(opts medial.Options) (medial.Graph, error) {
+	return medial.Axis([][]vector2.Vector2{p}, opts)
+}
+
+// MedialAxis returns the medial axis of the region, running between its
+// outer rings and around its holes.
+func (r Region) MedialAxis(opts medial.Options) (medial.Graph, error) {
+	normalized := r.Normalized()
+	rings := make([][]vector2.Vector2, len(normalized.Rings))
+	for i, ring := range normalized.Rings {
+		rings[i] = ring
+	}
+	return medial.Axis(rings, opts)
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type Mesh struct {
-	Polygon      Polygon
+	Region       Region
 	Position     vector2.Vector2
 	Color        [4]float64
 	OutlineWidth float64
@@ -22,59 +22,71 @@ func NewMesh() *Mesh {
 }
 
 func (m Mesh) Scale(factor float64) Mesh {
-	for i, p := range m.Polygon {
-		m.Polygon[i] = p.Mulf(factor)
-	}
+	m.Region = m.Region.Scale(factor).(Region)
 	return m
 }
 
 func (m *Mesh) GetBoundingBox() vector2.Vector2 {
-	minX, minY := m.Polygon[0].X, m.Polygon[0].Y
-	maxX, maxY := m.Polygon[0].X, m.Polygon[0].Y
-
-	for _, p := range m.Polygon {
-		if p.X < minX {
-			minX = p.X
-		}
-		if p.Y < minY {
-			minY = p.Y
-		}
-		if p.X > maxX {
-			maxX = p.X
-		}
-		if p.Y > maxY {
-			maxY = p.Y
-		}
-	}
+	return m.Region.GetBoundingBox().Size
+}
 
-	return vector2.Vector2{X: maxX - minX, Y: maxY - minY}
+// Placed returns a copy of the mesh's region moved to Position.
+func (m *Mesh) Placed() Region {
+	return m.Region.Translate(m.Position.X, m.Position.Y).(Region)
 }
 
 // Member functions
 func (m *Mesh) Draw(context *gg.Context) {
+	placed := m.Placed()
 	if m.Filled {
-		m.Polygon.DrawFilled(context, m.Color)
+		placed.DrawFilled(context, m.Color)
 	}
-	m.Polygon.Draw(context, m.OutlineColor, m.OutlineWidth)
+	placed.Draw(context, m.OutlineColor, m.OutlineWidth)
+}
+
+// SignedDistance returns the signed distance to the mesh's region placed at
+// Position.
+func (m *Mesh) SignedDistance(p vector2.Vector2) float64 {
+	return m.Region.SignedDistance(p.Sub(m.Position))
+}
+
+// SampleDistance returns the signed distance with the nearest point of the
+// mesh's region placed at Position.
+func (m *Mesh) SampleDistance(p vector2.Vector2) DistanceSample {
+	sample := m.Region.SampleDistance(p.Sub(m.Position))
+	sample.Closest = sample.Closest.Add(m.Position)
+	return sample
+}
+
+func (m *Mesh) AddShape(s Shape) Region {
+	return m.applyBoolean(s, Union)
+}
+
+func (m *Mesh) SubtractShape(s Shape) Region {
+	return m.applyBoolean(s, Difference)
 }
 
-func (m *Mesh) SignedDistance(x, y int) float64 {
-	return m.Polygon.SignedDistance(x, y)
+func (m *Mesh) IntersectShape(s Shape) Region {
+	return m.applyBoolean(s, Intersection)
 }
 
-func (m *Mesh) AddShape(s Shape) {
-	bg := BooleanGroup([]Shape{m.Polygon, s})
-	m.Polygon = Polygon(bg.GetContour(0, bg.UnionDistance))
+func (m *Mesh) XorShape(s Shape) Region {
+	return m.applyBoolean(s, Xor)
 }
 
-func (m *Mesh) SubtractShape(s Shape) {
-	bg := BooleanGroup([]Shape{m.Polygon, s})
-	m.Polygon = Polygon(bg.GetContour(0, bg.DifferenceDistance))
+// applyBoolean clips the mesh region against s using the vector boolean
+// engine. Every resulting outline and hole is kept. Like the distance
+// queries, s is taken in the frame where the region is placed at Position, so
+// it is moved into region-local coordinates first.
+func (m *Mesh) applyBoolean(s Shape, op BooleanOperation) Region {
+	operand := RegionFromShape(s, DefaultTolerance).Translate(-m.Position.X, -m.Position.Y).(Region)
+	m.Region = ClipRegions(m.Region, operand, op)
+	return m.Region
 }
 
-func (m *Mesh) IntersectShape(s Shape) {
-	bg := BooleanGroup([]Shape{m.Polygon, s})
-	m.Polygon = Polygon(bg.GetContour(0, bg.IntersectionDistance))
+// placedRings returns the normalized rings of the region placed at Position.
+func (m *Mesh) placedRings() []Polygon {
+	return m.Placed().Normalized().Rings
 }
 
 func simplifyContours(c []contourmap.Contour, epsilon float64) [][]vector2.Vector2 {
@@ -0,0 +1,57 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/fogleman/gg"
+)
+
+func TestMeshBooleanAndPocketArePlaced(t *testing.T) {
+	m := NewMesh()
+	m.Region = NewRegion(NonZero, square(0, 0, 10))
+	m.Position = vector2.Vector2{X: 100, Y: 0}
+
+	m.AddShape(square(105, 0, 10))
+	if area := m.Region.Area(); math.Abs(area-150) > 1e-6 {
+		t.Errorf("area after union = %g, want 150", area)
+	}
+	if d := m.SignedDistance(vector2.Vector2{X: 112, Y: 5}); d >= 0 {
+		t.Errorf("distance to the added square's center = %g, want it inside", d)
+	}
+
+	pocket := m.Pocket(2, 1)
+	if len(pocket.Loops) == 0 {
+		t.Fatal("no loops")
+	}
+	for _, loop := range pocket.Loops {
+		for _, p := range loop.Path {
+			if d := m.SignedDistance(p); d > -1+1e-6 {
+				t.Fatalf("pocket point %v is %g from the placed wall, want at least the tool radius inside", p, d)
+			}
+		}
+	}
+}
+
+func TestMeshDrawsAtPosition(t *testing.T) {
+	m := NewMesh()
+	m.Region = NewRegion(NonZero, square(0, 0, 10))
+	m.Position = vector2.Vector2{X: 20, Y: 0}
+	m.Filled = true
+	m.Color = [4]float64{1, 1, 1, 1}
+
+	if bounds := m.Placed().GetBoundingBox(); bounds.Position != m.Position {
+		t.Errorf("placed bounds start at %v, want %v", bounds.Position, m.Position)
+	}
+
+	dc := gg.NewContext(40, 20)
+	m.Draw(dc)
+	img := dc.Image()
+	if _, _, _, a := img.At(25, 5).RGBA(); a == 0 {
+		t.Error("nothing drawn at the mesh position")
+	}
+	if _, _, _, a := img.At(5, 5).RGBA(); a != 0 {
+		t.Error("mesh drawn at the origin rather than its position")
+	}
+}
@@ -0,0 +1,130 @@
+package primitive
+
+import (
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/minkowski"
+)
+
+// MinkowskiSum returns every point p + q with p in the polygon and q in s,
+// such as the area swept by a tool of shape s whose reference point follows
+// the polygon, or a configuration space obstacle. Circles give exact round
+// offsets. Lines and arcs are swept as curves; other curved shapes are
+// sampled within tolerance, and a tolerance of zero selects
+// DefaultTolerance. The result is normalized and may have holes.
+func (p Polygon) MinkowskiSum(s Shape, tolerance float64) Region {
+	return minkowskiSum(Region{Rings: []Polygon{p}}, s, tolerance, false)
+}
+
+// MinkowskiDifference returns every point p - q with p in the polygon and q
+// in s, which is the sum with s mirrored through the origin. The two shapes
+// overlap exactly when the difference contains the origin.
+func (p Polygon) MinkowskiDifference(s Shape, tolerance float64) Region {
+	return minkowskiSum(Region{Rings: []Polygon{p}}, s, tolerance, true)
+}
+
+// MinkowskiSum returns every point p + q with p in the region and q in s, as
+// Polygon.MinkowskiSum.
+func (r Region) MinkowskiSum(s Shape, tolerance float64) Region {
+	return minkowskiSum(r, s, tolerance, false)
+}
+
+// MinkowskiDifference returns every point p - q with p in the region and q
+// in s, as Polygon.MinkowskiDifference.
+func (r Region) MinkowskiDifference(s Shape, tolerance float64) Region {
+	return minkowskiSum(r, s, tolerance, true)
+}
+
+func minkowskiSum(r Region, s Shape, tolerance float64, mirror bool) Region {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	sign := 1.0
+	if mirror {
+		sign = -1
+	}
+	a := r.Normalized()
+	if a.IsEmpty() {
+		return Region{}
+	}
+
+	if c, ok := s.(Circle); ok {
+		sum := a
+		if c.Radius > 0 {
+			sum = a.Offset(c.Radius, OffsetOptions{Join: JoinRound, ArcTolerance: tolerance})
+		}
+		return translateRings(sum.Rings, c.Center.Mulf(sign))
+	}
+
+	b := minkowskiOperand(s, tolerance)
+	if len(b) == 0 {
+		return Region{}
+	}
+	for _, ring := range b {
+		for i := range ring {
+			ring[i] = ring[i].Mulf(sign)
+		}
+	}
+
+	if len(a.Rings) == 1 && len(b) == 1 && minkowski.IsConvex(a.Rings[0]) && minkowski.IsConvex(b[0]) {
+		return Region{Rings: []Polygon{Polygon(minkowski.ConvexSum(a.Rings[0], b[0]))}}
+	}
+
+	// The sum is the area swept by the outlines against each other, filled
+	// in by one copy of a placed on b and one copy of b placed on each ring
+	// of a.
+	aRings := make([][]vector2.Vector2, len(a.Rings))
+	for i, ring := range a.Rings {
+		aRings[i] = ring
+	}
+	bRings := make([][]vector2.Vector2, len(b))
+	for i, ring := range b {
+		bRings[i] = ring
+	}
+	var pieces []Polygon
+	for _, piece := range minkowski.Convolution(aRings, bRings) {
+		pieces = append(pieces, piece)
+	}
+	pieces = append(pieces, translateRings(a.Rings, b[0][0]).Rings...)
+	sum := clipRings(pieces, NonZero, nil, NonZero, Union)
+	for _, ring := range a.Rings {
+		sum = clipRings(sum, NonZero, translateRings(b, ring[0]).Rings, NonZero, Union)
+	}
+	return Region{Rings: sum}
+}
+
+// minkowskiOperand returns the rings of a shape to be summed, as copies that
+// may be modified. Curves come back as paths traced there and back, so that
+// they sweep without enclosing anything.
+func minkowskiOperand(s Shape, tolerance float64) []Polygon {
+	var path Polygon
+	switch s := s.(type) {
+	case Line:
+		path = Polygon{s.Start, s.End}
+	case Arc, EllipticalArc, QuadraticBezier, CubicBezier, BSpline:
+		path = Polygonize(s, tolerance)
+	case Region:
+		return s.Normalized().Rings
+	default:
+		p := Polygonize(s, tolerance)
+		if len(p) < 3 {
+			return nil
+		}
+		return NewRegion(NonZero, p).Normalized().Rings
+	}
+	ring := append(Polygon{}, path...)
+	for i := len(path) - 2; i > 0; i-- {
+		ring = append(ring, path[i])
+	}
+	return []Polygon{ring}
+}
+
+func translateRings(rings []Polygon, offset vector2.Vector2) Region {
+	out := make([]Polygon, len(rings))
+	for i, ring := range rings {
+		out[i] = make(Polygon, len(ring))
+		for j, v := range ring {
+			out[i][j] = v.Add(offset)
+		}
+	}
+	return Region{Rings: out}
+}
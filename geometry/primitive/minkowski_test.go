@@ -0,0 +1,72 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func TestMinkowskiSumOfSquares(t *testing.T) {
+	sum := square(0, 0, 2).MinkowskiSum(square(-1, -1, 2), 0)
+	if len(sum.Rings) != 1 {
+		t.Fatalf("got %d rings, want 1", len(sum.Rings))
+	}
+	want := square(-1, -1, 4)
+	ring := sum.Rings[0]
+	if len(ring) != 4 {
+		t.Fatalf("got %v, want %v", ring, want)
+	}
+	for _, w := range want {
+		found := false
+		for _, v := range ring {
+			found = found || v.IsEqualApprox(w)
+		}
+		if !found {
+			t.Errorf("got %v, want %v", ring, want)
+		}
+	}
+}
+
+func TestMinkowskiSumAreas(t *testing.T) {
+	// An L made of three unit-two squares, grown by one in every axis
+	// direction, fills a 6×6 square less its 2×2 notch.
+	l := Polygon{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 4}, {X: 0, Y: 4}}
+	for _, tt := range []struct {
+		name string
+		sum  Region
+		want float64
+		tol  float64
+	}{
+		{"L ⊕ square", l.MinkowskiSum(square(-1, -1, 2), 0), 32, 1e-9},
+		{"square ⊕ line", square(0, 0, 2).MinkowskiSum(NewLine(0, 0, 3, 0), 0), 10, 1e-9},
+		{"square ⊕ circle", square(0, 0, 2).MinkowskiSum(NewCircle(5, 5, 1), 0.001), 4 + 8 + math.Pi, 0.01},
+	} {
+		if got := totalArea(tt.sum.Rings); math.Abs(got-tt.want) > tt.tol {
+			t.Errorf("%s: got area %g, want %g", tt.name, got, tt.want)
+		}
+	}
+
+	// A circle moves the sum by its center.
+	box := square(0, 0, 2).MinkowskiSum(NewCircle(5, 5, 1), 0.001).GetBoundingBox()
+	if !box.Position.IsEqualApprox(vector2.Vector2{X: 4, Y: 4}) {
+		t.Errorf("circle sum starts at %v, want (4, 4)", box.Position)
+	}
+}
+
+func TestMinkowskiDifferenceDetectsOverlap(t *testing.T) {
+	a := square(0, 0, 2)
+	origin := vector2.Vector2{}
+	for _, tt := range []struct {
+		other Polygon
+		want  bool
+	}{
+		{square(1, 1, 2), true},
+		{square(3, 0, 2), false},
+	} {
+		diff := a.MinkowskiDifference(tt.other, 0)
+		if got := diff.Contains(origin, ContainOptions{}); got != tt.want {
+			t.Errorf("difference with %v contains the origin = %v, want %v", tt.other, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package primitive
+
+import (
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	clipper "github.com/ctessum/go.clipper"
+)
+
+// JoinType defines how offset edges are connected at convex corners.
+type JoinType int
+
+const (
+	JoinSquare JoinType = iota
+	JoinRound
+	JoinMiter
+)
+
+// EndType defines how the ends of an offset path are treated.
+type EndType int
+
+const (
+	EndClosedPolygon EndType = iota
+	EndClosedLine
+	EndOpenButt
+	EndOpenSquare
+	EndOpenRound
+)
+
+// OffsetOptions configures a polygon or polyline offset.
+type OffsetOptions struct {
+	Join JoinType
+	End  EndType
+	// MiterLimit is the maximum distance, in multiples of the offset, that a
+	// miter join may extend before it is squared off. Values below 2 are
+	// raised to 2.
+	MiterLimit float64
+	// ArcTolerance is the maximum deviation of round joins and ends from the
+	// true arc. Zero selects a quarter of a unit.
+	ArcTolerance float64
+}
+
+// DefaultOffsetOptions returns miter joins on closed polygons.
+func DefaultOffsetOptions() OffsetOptions {
+	return OffsetOptions{
+		Join:       JoinMiter,
+		End:        EndClosedPolygon,
+		MiterLimit: 2.0,
+	}
+}
+
+// Offset grows the polygon by delta, or shrinks it when delta is negative.
+// Self-intersections produced by the offset are resolved, so a shape that
+// pinches apart is returned as several polygons and a shape that vanishes
+// returns none.
+func (p Polygon) Offset(delta float64, opts OffsetOptions) []Polygon {
+	opts.End = EndClosedPolygon
+	return OffsetPolygons([]Polygon{p}, delta, opts)
+}
+
+// OffsetPolygons offsets a set of rings together. Rings with the opposite
+// orientation of the outermost ring are treated as holes and move the other
+// way, so islands and cut-outs are handled in a single pass.
+func OffsetPolygons(polygons []Polygon, delta float64, opts OffsetOptions) []Polygon {
+	co := clipper.NewClipperOffset()
+	co.MiterLimit = opts.MiterLimit
+	co.ArcTolerance = opts.ArcTolerance * ClipScale
+	if opts.ArcTolerance <= 0 {
+		co.ArcTolerance = 0.25 * ClipScale
+	}
+	for _, p := range polygons {
+		if len(p) > 1 {
+			co.AddPath(toClipperPath(p), toClipperJoin(opts.Join), toClipperEnd(opts.End))
+		}
+	}
+	return fromClipperPaths(co.Execute(delta * ClipScale))
+}
+
+// OffsetPolyline offsets an open path by delta on both sides. End types of
+// EndClosedPolygon are treated as EndClosedLine, since a polyline has no
+// interior.
+func OffsetPolyline(path []vector2.Vector2, delta float64, opts OffsetOptions) []Polygon {
+	if opts.End == EndClosedPolygon {
+		opts.End = EndClosedLine
+	}
+	return OffsetPolygons([]Polygon{Polygon(path)}, delta, opts)
+}
+
+func toClipperJoin(j JoinType) clipper.JoinType {
+	switch j {
+	case JoinRound:
+		return clipper.JtRound
+	case JoinMiter:
+		return clipper.JtMiter
+	}
+	return clipper.JtSquare
+}
+
+func toClipperEnd(e EndType) clipper.EndType {
+	switch e {
+	case EndClosedLine:
+		return clipper.EtClosedLine
+	case EndOpenButt:
+		return clipper.EtOpenButt
+	case EndOpenSquare:
+		return clipper.EtOpenSquare
+	case EndOpenRound:
+		return clipper.EtOpenRound
+	}
+	return clipper.EtClosedPolygon
+}
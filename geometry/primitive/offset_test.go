@@ -0,0 +1,49 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOffsetSquare(t *testing.T) {
+	ring := square(0, 0, 10)
+	for _, tc := range []struct {
+		join  JoinType
+		delta float64
+		area  float64
+	}{
+		{JoinMiter, 1, 144},
+		{JoinRound, 1, 140 + math.Pi},
+		{JoinSquare, 1, 144 - 4*(math.Sqrt2-1)*(math.Sqrt2-1)},
+		{JoinMiter, -1, 64},
+		{JoinRound, -1, 64},
+		{JoinSquare, -1, 64},
+	} {
+		opts := DefaultOffsetOptions()
+		opts.Join = tc.join
+		opts.ArcTolerance = 0.001
+		got := ring.Offset(tc.delta, opts)
+		if len(got) != 1 {
+			t.Fatalf("join %v delta %g: got %d polygons, want 1", tc.join, tc.delta, len(got))
+		}
+		if area := signedArea(got[0]); math.Abs(area-tc.area) > 0.01 {
+			t.Errorf("join %v delta %g: area = %g, want %g", tc.join, tc.delta, area, tc.area)
+		}
+	}
+
+	if got := ring.Offset(-6, DefaultOffsetOptions()); len(got) != 0 {
+		t.Errorf("got %d polygons after shrinking past the center, want none", len(got))
+	}
+}
+
+func TestOffsetKeepsHoles(t *testing.T) {
+	hole := square(3, 3, 4)
+	reverse(hole)
+	got := OffsetPolygons([]Polygon{square(0, 0, 10), hole}, -1, DefaultOffsetOptions())
+	if len(got) != 2 {
+		t.Fatalf("got %d rings, want an outline and a hole", len(got))
+	}
+	if area := totalArea(got); math.Abs(area-(64-36)) > 1e-6 {
+		t.Errorf("area = %g, want %g", area, 64.0-36)
+	}
+}
@@ -0,0 +1,195 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/geometry2d"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// maxPocketDepth bounds the number of inward offsets of a single pocket.
+const maxPocketDepth = 100000
+
+// PocketLoop is a single closed pass of a contour-parallel pocket.
+type PocketLoop struct {
+	Path Polygon
+	// Depth is the number of stepovers between the loop and the pocket wall,
+	// 0 for the loops that finish the wall.
+	Depth int
+	// Order is the position of the loop in the cutting sequence.
+	Order int
+	// Parent is the index of the loop one stepover further out, or -1 for
+	// loops at depth 0.
+	Parent int
+}
+
+// PocketPath is a continuous tool move. The tool only retracts between paths.
+type PocketPath struct {
+	Points []vector2.Vector2
+	// Loops are the indices of the loops cut by this path, in cutting order.
+	Loops []int
+}
+
+// Pocket is a contour-parallel clearing strategy for a region.
+type Pocket struct {
+	Loops []PocketLoop
+	Paths []PocketPath
+}
+
+// Pocket clears the mesh region with nested inward offset loops. The tool
+// center follows loops spaced stepover apart, starting half a tool diameter
+// inside the wall. The loops are placed with the region at Position.
+func (m *Mesh) Pocket(toolDiameter, stepover float64) Pocket {
+	return PocketPolygons(m.placedRings(), toolDiameter, stepover)
+}
+
+// PocketPolygons generates a contour-parallel pocket for a set of rings.
+// Rings with the opposite orientation of the outer boundary are islands and
+// are left standing. Loops are cut from the innermost outwards and linked
+// into continuous paths wherever the link stays inside the cleared area. A
+// stepover wider than the tool would leave material standing between loops,
+// so it is clamped to the tool diameter.
+func PocketPolygons(rings []Polygon, toolDiameter, stepover float64) Pocket {
+	pocket := Pocket{}
+	if toolDiameter <= 0 || stepover <= 0 {
+		return pocket
+	}
+	stepover = math.Min(stepover, toolDiameter)
+
+	opts := DefaultOffsetOptions()
+	opts.Join = JoinRound
+	opts.ArcTolerance = toolDiameter * 0.001
+
+	level := OffsetPolygons(rings, -toolDiameter/2, opts)
+	var previous []int
+	for depth := 0; len(level) > 0 && depth < maxPocketDepth; depth++ {
+		var current []int
+		for _, ring := range level {
+			pocket.Loops = append(pocket.Loops, PocketLoop{
+				Path:   ring,
+				Depth:  depth,
+				Parent: parentLoop(pocket.Loops, previous, ring),
+			})
+			current = append(current, len(pocket.Loops)-1)
+		}
+		previous = current
+		level = OffsetPolygons(level, -stepover, opts)
+	}
+
+	pocket.order()
+	pocket.link()
+	return pocket
+}
+
+// order numbers the loops so that every loop is cut after all of the loops
+// nested inside it.
+func (pocket *Pocket) order() {
+	children := make([][]int, len(pocket.Loops))
+	var roots []int
+	for i, loop := range pocket.Loops {
+		if loop.Parent < 0 {
+			roots = append(roots, i)
+		} else {
+			children[loop.Parent] = append(children[loop.Parent], i)
+		}
+	}
+
+	next := 0
+	var visit func(i int)
+	visit = func(i int) {
+		for _, c := range children[i] {
+			visit(c)
+		}
+		pocket.Loops[i].Order = next
+		next++
+	}
+	for _, r := range roots {
+		visit(r)
+	}
+}
+
+// link joins consecutive loops into continuous paths. A loop is entered
+// straight from the previous one when it is that loop's parent and the move
+// stays inside the area the tool may reach at the parent's depth, bounded by
+// the parent and the island loops beside it. Otherwise the tool retracts.
+func (pocket *Pocket) link() {
+	sequence := make([]int, len(pocket.Loops))
+	var levels [][]Polygon
+	for i, loop := range pocket.Loops {
+		sequence[loop.Order] = i
+		for len(levels) <= loop.Depth {
+			levels = append(levels, nil)
+		}
+		levels[loop.Depth] = append(levels[loop.Depth], loop.Path)
+	}
+
+	var path *PocketPath
+	last := -1
+	for _, i := range sequence {
+		loop := pocket.Loops[i]
+		start := 0
+		linked := path != nil && pocket.Loops[last].Parent == i
+		if linked {
+			from := path.Points[len(path.Points)-1]
+			start = nearestVertex(loop.Path, from)
+			linked = linkInside(levels[loop.Depth], from, loop.Path[start])
+		}
+		if !linked {
+			pocket.Paths = append(pocket.Paths, PocketPath{})
+			path = &pocket.Paths[len(pocket.Paths)-1]
+			start = 0
+		}
+
+		for k := 0; k <= len(loop.Path); k++ {
+			path.Points = append(path.Points, loop.Path[(start+k)%len(loop.Path)])
+		}
+		path.Loops = append(path.Loops, i)
+		last = i
+	}
+}
+
+// parentLoop returns the loop among candidates that ring was offset from, or
+// -1 when there is none. The parent has the same orientation as the ring: an
+// outer ring lies inside its parent, and an island's loop surrounds the
+// smaller loop it grew from. When several loops qualify, the one whose
+// outline is closest to the first vertex of ring is used.
+func parentLoop(loops []PocketLoop, candidates []int, ring Polygon) int {
+	outer := signedArea(ring) > 0
+	best := -1
+	bestDist := math.Inf(1)
+	for _, c := range candidates {
+		path := loops[c].Path
+		if (signedArea(path) > 0) != outer {
+			continue
+		}
+		if outer && windingNumber(path, ring[0]) == 0 || !outer && windingNumber(ring, path[0]) == 0 {
+			continue
+		}
+		if d := ringDistance(path, ring[0]); d < bestDist {
+			bestDist = d
+			best = c
+		}
+	}
+	return best
+}
+
+func ringDistance(ring Polygon, point vector2.Vector2) float64 {
+	d := math.Inf(1)
+	for i := range ring {
+		segment := [2]vector2.Vector2{ring[i], ring[(i+1)%len(ring)]}
+		d = math.Min(d, geometry2d.GetDistanceSquaredToSegment(point, segment))
+	}
+	return math.Sqrt(d)
+}
+
+func nearestVertex(ring Polygon, point vector2.Vector2) int {
+	best := 0
+	bestDist := math.Inf(1)
+	for i, v := range ring {
+		if d := v.DistanceSquaredTo(point); d < bestDist {
+			bestDist = d
+			best = i
+		}
+	}
+	return best
+}
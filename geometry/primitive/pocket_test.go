@@ -0,0 +1,96 @@
+package primitive
+
+import "testing"
+
+func square(x, y, size float64) Polygon {
+	return Polygon{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}
+}
+
+func TestPocketParentsWithIsland(t *testing.T) {
+	island := square(40, 40, 20)
+	reverse(island)
+	pocket := PocketPolygons([]Polygon{square(0, 0, 100), island}, 6, 3)
+	if len(pocket.Loops) == 0 {
+		t.Fatal("no loops")
+	}
+	holes := 0
+	for i, loop := range pocket.Loops {
+		if loop.Depth == 0 {
+			if loop.Parent != -1 {
+				t.Errorf("loop %d at depth 0 has parent %d", i, loop.Parent)
+			}
+			continue
+		}
+		if loop.Parent < 0 {
+			t.Fatalf("loop %d at depth %d has no parent", i, loop.Depth)
+		}
+		parent := pocket.Loops[loop.Parent]
+		if parent.Depth != loop.Depth-1 {
+			t.Errorf("loop %d at depth %d has parent at depth %d", i, loop.Depth, parent.Depth)
+		}
+		outer := signedArea(loop.Path) > 0
+		if (signedArea(parent.Path) > 0) != outer {
+			t.Errorf("loop %d and its parent %d have opposite orientations", i, loop.Parent)
+		}
+		if outer && windingNumber(parent.Path, loop.Path[0]) == 0 {
+			t.Errorf("outer loop %d lies outside its parent %d", i, loop.Parent)
+		}
+		if !outer {
+			holes++
+			if windingNumber(loop.Path, parent.Path[0]) == 0 {
+				t.Errorf("island loop %d does not surround its parent %d", i, loop.Parent)
+			}
+		}
+		if parent.Order <= loop.Order {
+			t.Errorf("loop %d is cut after its parent %d", i, loop.Parent)
+		}
+	}
+	if holes == 0 {
+		t.Error("expected loops around the island")
+	}
+
+	// Every path starts on a fresh loop and each link stays with its parent.
+	for _, path := range pocket.Paths {
+		for k := 1; k < len(path.Loops); k++ {
+			if pocket.Loops[path.Loops[k-1]].Parent != path.Loops[k] {
+				t.Errorf("path links loop %d to %d, which is not its parent", path.Loops[k-1], path.Loops[k])
+			}
+		}
+	}
+}
+
+func TestPocketClampsStepover(t *testing.T) {
+	rings := []Polygon{square(0, 0, 100)}
+	wide := PocketPolygons(rings, 10, 25)
+	clamped := PocketPolygons(rings, 10, 10)
+	if len(wide.Loops) != len(clamped.Loops) {
+		t.Fatalf("got %d loops with a stepover of 25, want %d as with 10", len(wide.Loops), len(clamped.Loops))
+	}
+	for i := 1; i < len(wide.Loops); i++ {
+		a, b := wide.Loops[i-1].Path, wide.Loops[i].Path
+		if gap := ringDistance(a, b[0]); gap > 10+1e-6 {
+			t.Errorf("loops %d and %d are %g apart, wider than the tool", i-1, i, gap)
+		}
+	}
+}
+
+func TestPocketLinksAvoidIslands(t *testing.T) {
+	// A long island near the top wall sits between nested loops.
+	island := Polygon{{X: 10, Y: 70}, {X: 10, Y: 80}, {X: 90, Y: 80}, {X: 90, Y: 70}}
+	rings := []Polygon{square(0, 0, 100), island}
+	pocket := PocketPolygons(rings, 6, 3)
+
+	opts := DefaultOffsetOptions()
+	opts.Join = JoinRound
+	opts.ArcTolerance = 0.006
+	reach := OffsetPolygons(rings, -3+1e-3, opts)
+	for i, path := range pocket.Paths {
+		at := 0
+		for k, loop := range path.Loops {
+			if k > 0 && !linkInside(reach, path.Points[at-1], path.Points[at]) {
+				t.Errorf("path %d links into loop %d across uncut material: %v to %v", i, loop, path.Points[at-1], path.Points[at])
+			}
+			at += len(pocket.Loops[loop].Path) + 1
+		}
+	}
+}
@@ -80,8 +80,21 @@ func (p Polygon) DrawFilled(dc *gg.Context, color [4]float64) {
 	dc.Pop()
 }
 
-func (p Polygon) SignedDistance(x, y int) float64 {
-	return SdPolygon(p, vector2.Vector2{X: float64(x), Y: float64(y)})
+// SignedDistance returns the distance to the nearest edge, negative inside
+// the polygon by the even-odd rule.
+func (p Polygon) SignedDistance(point vector2.Vector2) float64 {
+	if len(p) == 0 {
+		return math.Inf(1)
+	}
+	return SdPolygon(p, point)
+}
+
+// SampleDistance returns the signed distance with the nearest point of the
+// outline, as SignedDistance.
+func (p Polygon) SampleDistance(point vector2.Vector2) DistanceSample {
+	return ringsSample([]Polygon{p}, point, func(q vector2.Vector2) bool {
+		return insideWinding(windingNumber(p, q), EvenOdd)
+	})
 }
 
 func SdPolygon(vertices []vector2.Vector2, p vector2.Vector2) float64 {
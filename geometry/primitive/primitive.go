@@ -2,6 +2,8 @@ package primitive
 
 import (
 	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
 	"github.com/fogleman/gg"
 )
 
@@ -9,10 +11,13 @@ type Shape interface {
 	Draw(dc *gg.Context, color [4]float64, lwidth float64)
 	DrawDashed(dc *gg.Context, color [4]float64, lwidth float64, dashLength float64, dashInterval float64)
 	DrawFilled(dc *gg.Context, color [4]float64)
-	SignedDistance(x, y int) float64
+	SignedDistance(p vector2.Vector2) float64
+	SampleDistance(p vector2.Vector2) DistanceSample
 	GetBoundingBox() rect2.Rect2
 	Scale(factor float64) Shape
 	Translate(offsetX, offsetY float64) Shape
+	Transform(m algebra.Affine) Shape
+	Contains(p vector2.Vector2, opts ContainOptions) bool
 }
 
 type DrawStyle int
@@ -29,4 +34,5 @@ const (
 	Union BooleanOperation = iota
 	Difference
 	Intersection
+	Xor
 )
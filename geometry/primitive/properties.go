@@ -0,0 +1,228 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// Properties are the exact area properties of a closed shape. Second moments
+// are taken about axes through the centroid, parallel to x and y:
+// Ixx = ∫y² dA, Iyy = ∫x² dA and Ixy = ∫xy dA.
+type Properties struct {
+	Area float64
+	// SignedArea is positive for counter-clockwise outlines in a y-up frame
+	// and negative for clockwise ones.
+	SignedArea    float64
+	Perimeter     float64
+	Centroid      vector2.Vector2
+	Ixx, Iyy, Ixy float64
+}
+
+// Polar returns the polar moment about the centroid.
+func (p Properties) Polar() float64 {
+	return p.Ixx + p.Iyy
+}
+
+// About returns the second moments about axes through point, using the
+// parallel axis theorem.
+func (p Properties) About(point vector2.Vector2) (ixx, iyy, ixy float64) {
+	d := p.Centroid.Sub(point)
+	return p.Ixx + p.Area*d.Y*d.Y, p.Iyy + p.Area*d.X*d.X, p.Ixy + p.Area*d.X*d.Y
+}
+
+// Add combines the properties of two disjoint parts.
+func (p Properties) Add(q Properties) Properties {
+	return p.rawMoments().add(q.rawMoments(), 1).properties(p.Perimeter+q.Perimeter, p.SignedArea+q.SignedArea)
+}
+
+// Subtract removes a hole that lies inside p. The hole outline adds to the
+// perimeter.
+func (p Properties) Subtract(hole Properties) Properties {
+	return p.rawMoments().add(hole.rawMoments(), -1).properties(p.Perimeter+hole.Perimeter, p.SignedArea-hole.SignedArea)
+}
+
+// moments are area integrals about the origin: ∫1, ∫x, ∫y, ∫x², ∫y², ∫xy.
+type moments struct {
+	a, x, y, xx, yy, xy float64
+}
+
+func (m moments) add(n moments, sign float64) moments {
+	return moments{m.a + sign*n.a, m.x + sign*n.x, m.y + sign*n.y, m.xx + sign*n.xx, m.yy + sign*n.yy, m.xy + sign*n.xy}
+}
+
+// translated returns the moments of the same area moved by offset.
+func (m moments) translated(offset vector2.Vector2) moments {
+	cx, cy := offset.X, offset.Y
+	return moments{
+		a:  m.a,
+		x:  m.x + cx*m.a,
+		y:  m.y + cy*m.a,
+		xx: m.xx + 2*cx*m.x + cx*cx*m.a,
+		yy: m.yy + 2*cy*m.y + cy*cy*m.a,
+		xy: m.xy + cx*m.y + cy*m.x + cx*cy*m.a,
+	}
+}
+
+// properties converts origin moments into centroidal properties. Moments of
+// a clockwise outline come out negative and are flipped.
+func (m moments) properties(perimeter, signedArea float64) Properties {
+	if m.a < 0 {
+		m = moments{}.add(m, -1)
+	}
+	p := Properties{Area: m.a, SignedArea: signedArea, Perimeter: perimeter}
+	if m.a == 0 {
+		return p
+	}
+	p.Centroid = vector2.Vector2{X: m.x / m.a, Y: m.y / m.a}
+	p.Ixx = m.yy - m.a*p.Centroid.Y*p.Centroid.Y
+	p.Iyy = m.xx - m.a*p.Centroid.X*p.Centroid.X
+	p.Ixy = m.xy - m.a*p.Centroid.X*p.Centroid.Y
+	return p
+}
+
+func (p Properties) rawMoments() moments {
+	c := p.Centroid
+	return moments{
+		a:  p.Area,
+		x:  p.Area * c.X,
+		y:  p.Area * c.Y,
+		xx: p.Iyy + p.Area*c.X*c.X,
+		yy: p.Ixx + p.Area*c.Y*c.Y,
+		xy: p.Ixy + p.Area*c.X*c.Y,
+	}
+}
+
+// ringMoments integrates over the area enclosed by a ring with Green's
+// theorem. The result is signed by the ring orientation.
+func ringMoments(ring []vector2.Vector2) moments {
+	var m moments
+	n := len(ring)
+	for i := 0; i < n; i++ {
+		a, b := ring[i], ring[(i+1)%n]
+		cross := a.X*b.Y - b.X*a.Y
+		m.a += cross / 2
+		m.x += cross * (a.X + b.X) / 6
+		m.y += cross * (a.Y + b.Y) / 6
+		m.xx += cross * (a.X*a.X + a.X*b.X + b.X*b.X) / 12
+		m.yy += cross * (a.Y*a.Y + a.Y*b.Y + b.Y*b.Y) / 12
+		m.xy += cross * (a.X*b.Y + 2*a.X*a.Y + 2*b.X*b.Y + b.X*a.Y) / 24
+	}
+	return m
+}
+
+func ringPerimeter(ring []vector2.Vector2) float64 {
+	perimeter := 0.0
+	for i := range ring {
+		perimeter += ring[i].DistanceTo(ring[(i+1)%len(ring)])
+	}
+	return perimeter
+}
+
+func (p Polygon) Properties() Properties {
+	m := ringMoments(p)
+	return m.properties(ringPerimeter(p), m.a)
+}
+
+// Properties of the region, with holes subtracted. The rings are normalized
+// under the region's fill rule first.
+func (r Region) Properties() Properties {
+	var m moments
+	perimeter := 0.0
+	for _, ring := range r.Normalized().Rings {
+		m = m.add(ringMoments(ring), 1)
+		perimeter += ringPerimeter(ring)
+	}
+	return m.properties(perimeter, m.a)
+}
+
+func (c Circle) Properties() Properties {
+	r := c.Radius
+	area := math.Pi * r * r
+	i := math.Pi * r * r * r * r / 4
+	return Properties{
+		Area:       area,
+		SignedArea: area,
+		Perimeter:  2 * math.Pi * r,
+		Centroid:   c.Center,
+		Ixx:        i,
+		Iyy:        i,
+	}
+}
+
+func (r Rectangle) Properties() Properties {
+	w, h := math.Abs(r.Size.X), math.Abs(r.Size.Y)
+	return Properties{
+		Area:       w * h,
+		SignedArea: r.Size.X * r.Size.Y,
+		Perimeter:  2 * (w + h),
+		Centroid:   r.Offset.Add(r.Size.Mulf(0.5)),
+		Ixx:        w * h * h * h / 12,
+		Iyy:        h * w * w * w / 12,
+	}
+}
+
+func (e Ellipse) Properties() Properties {
+	a, b := math.Abs(e.Radii.X), math.Abs(e.Radii.Y)
+	area := math.Pi * a * b
+	// Moments along the ellipse's own axes, rotated into place.
+	alongX := math.Pi * a * a * a * b / 4
+	alongY := math.Pi * a * b * b * b / 4
+	sin, cos := math.Sincos(e.Rotation)
+	return Properties{
+		Area:       area,
+		SignedArea: area,
+		Perimeter:  ellipsePerimeter(a, b),
+		Centroid:   e.Center,
+		Iyy:        cos*cos*alongX + sin*sin*alongY,
+		Ixx:        sin*sin*alongX + cos*cos*alongY,
+		Ixy:        sin * cos * (alongX - alongY),
+	}
+}
+
+// ellipsePerimeter evaluates the complete elliptic integral of the second
+// kind with the arithmetic-geometric mean, which converges quadratically to
+// full precision.
+func ellipsePerimeter(a, b float64) float64 {
+	if a == 0 || b == 0 {
+		return 4 * math.Max(a, b)
+	}
+	an, bn := a, b
+	sum := (a*a - b*b) / 2
+	weight := 0.5
+	for i := 0; i < 64 && math.Abs(an-bn) > 1e-15*an; i++ {
+		cn := (an - bn) / 2
+		an, bn = (an+bn)/2, math.Sqrt(an*bn)
+		weight *= 2
+		sum += weight * cn * cn
+	}
+	return 4 * math.Pi / (an + bn) * (a*a - sum)
+}
+
+// Properties of the area enclosed by the arc, either the sector bounded by
+// the two radii or the segment bounded by the chord. A clockwise arc has a
+// negative SignedArea.
+func (a Arc) Properties(region ArcRegion) Properties {
+	r, sweep := a.Circle.Radius, a.Sweep()
+	if math.Abs(sweep) >= 2*math.Pi {
+		return a.Circle.Properties()
+	}
+	t0, t1 := a.AngleStart, a.AngleEnd
+	r4 := r * r * r * r / 4
+	sector := moments{
+		a:  r * r * sweep / 2,
+		x:  r * r * r / 3 * (math.Sin(t1) - math.Sin(t0)),
+		y:  r * r * r / 3 * (math.Cos(t0) - math.Cos(t1)),
+		xx: r4 * (sweep/2 + (math.Sin(2*t1)-math.Sin(2*t0))/4),
+		yy: r4 * (sweep/2 - (math.Sin(2*t1)-math.Sin(2*t0))/4),
+		xy: r4 * (math.Sin(t1)*math.Sin(t1) - math.Sin(t0)*math.Sin(t0)) / 2,
+	}.translated(a.Circle.Center)
+
+	arcLength := r * math.Abs(sweep)
+	if region == ArcSegment {
+		triangle := ringMoments([]vector2.Vector2{a.Circle.Center, a.StartPoint(), a.EndPoint()})
+		segment := sector.add(triangle, -1)
+		return segment.properties(arcLength+a.StartPoint().DistanceTo(a.EndPoint()), segment.a)
+	}
+	return sector.properties(arcLength+2*r, sector.a)
+}
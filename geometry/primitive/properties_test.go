@@ -0,0 +1,152 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// checkProperties compares every field within a relative tolerance of the
+// larger of the two values and one.
+func checkProperties(t *testing.T, name string, got, want Properties, tol float64) {
+	t.Helper()
+	near := func(a, b float64) bool {
+		return math.Abs(a-b) <= tol*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+	}
+	if !near(got.Area, want.Area) || !near(got.SignedArea, want.SignedArea) || !near(got.Perimeter, want.Perimeter) ||
+		!near(got.Centroid.X, want.Centroid.X) || !near(got.Centroid.Y, want.Centroid.Y) ||
+		!near(got.Ixx, want.Ixx) || !near(got.Iyy, want.Iyy) || !near(got.Ixy, want.Ixy) {
+		t.Errorf("%s:\n got %+v\nwant %+v", name, got, want)
+	}
+}
+
+// polygonOf samples n vertices of a closed curve counter-clockwise.
+func polygonOf(n int, at func(angle float64) vector2.Vector2) Polygon {
+	p := make(Polygon, n)
+	for i := range p {
+		p[i] = at(2 * math.Pi * float64(i) / float64(n))
+	}
+	return p
+}
+
+func TestRectangleProperties(t *testing.T) {
+	const w, h = 4.0, 6.0
+	want := Properties{
+		Area:       w * h,
+		SignedArea: w * h,
+		Perimeter:  2 * (w + h),
+		Centroid:   vector2.Vector2{X: 3, Y: 5},
+		Ixx:        w * h * h * h / 12,
+		Iyy:        h * w * w * w / 12,
+	}
+	checkProperties(t, "rectangle", NewRectangle(1, 2, w, h).Properties(), want, 1e-12)
+
+	corners := Polygon{{X: 1, Y: 2}, {X: 5, Y: 2}, {X: 5, Y: 8}, {X: 1, Y: 8}}
+	checkProperties(t, "polygon", corners.Properties(), want, 1e-12)
+
+	reverse(corners)
+	clockwise := want
+	clockwise.SignedArea = -want.Area
+	checkProperties(t, "clockwise polygon", corners.Properties(), clockwise, 1e-12)
+
+	// About a corner the moments are bh³/3, hb³/3 and b²h²/4.
+	ixx, iyy, ixy := want.About(vector2.Vector2{X: 1, Y: 2})
+	if math.Abs(ixx-w*h*h*h/3) > 1e-9 || math.Abs(iyy-h*w*w*w/3) > 1e-9 || math.Abs(ixy-w*w*h*h/4) > 1e-9 {
+		t.Errorf("About the corner = %g, %g, %g", ixx, iyy, ixy)
+	}
+	if got := want.Polar(); got != want.Ixx+want.Iyy {
+		t.Errorf("Polar() = %g", got)
+	}
+}
+
+func TestRegionProperties(t *testing.T) {
+	// A 10×10 square with a centred 4×4 hole.
+	hole := square(3, 3, 4)
+	reverse(hole)
+	got := NewRegion(NonZero, square(0, 0, 10), hole).Properties()
+	want := Properties{
+		Area:       100 - 16,
+		SignedArea: 100 - 16,
+		Perimeter:  40 + 16,
+		Centroid:   vector2.Vector2{X: 5, Y: 5},
+		Ixx:        (10000 - 256) / 12.0,
+		Iyy:        (10000 - 256) / 12.0,
+	}
+	checkProperties(t, "region", got, want, 1e-12)
+	checkProperties(t, "subtract", square(0, 0, 10).Properties().Subtract(square(3, 3, 4).Properties()), want, 1e-12)
+
+	// Two unit squares side by side make a 2×1 rectangle, less the shared
+	// edge in the perimeter.
+	pair := square(0, 0, 1).Properties().Add(square(1, 0, 1).Properties())
+	rect := NewRectangle(0, 0, 2, 1).Properties()
+	rect.Perimeter = 8
+	checkProperties(t, "add", pair, rect, 1e-12)
+}
+
+func TestCircleAndEllipseProperties(t *testing.T) {
+	const r = 2.0
+	c := NewCircle(1, -1, r)
+	want := Properties{
+		Area:       math.Pi * r * r,
+		SignedArea: math.Pi * r * r,
+		Perimeter:  2 * math.Pi * r,
+		Centroid:   vector2.Vector2{X: 1, Y: -1},
+		Ixx:        math.Pi * r * r * r * r / 4,
+		Iyy:        math.Pi * r * r * r * r / 4,
+	}
+	checkProperties(t, "circle", c.Properties(), want, 1e-12)
+	sampled := polygonOf(4096, func(a float64) vector2.Vector2 {
+		return vector2.Vector2{X: 1 + r*math.Cos(a), Y: -1 + r*math.Sin(a)}
+	})
+	checkProperties(t, "sampled circle", sampled.Properties(), want, 1e-5)
+
+	checkProperties(t, "round ellipse", NewEllipse(1, -1, r, r, 0.7).Properties(), want, 1e-12)
+
+	// The perimeter of an ellipse with semi-axes 2 and 1 is 4·2·E(√3/2).
+	if got := NewEllipse(0, 0, 2, 1, 0).Properties().Perimeter; math.Abs(got-9.688448220547675) > 1e-12 {
+		t.Errorf("ellipse perimeter = %.15g, want 9.688448220547675", got)
+	}
+	for _, rotation := range []float64{0, math.Pi / 2, math.Pi / 6} {
+		e := NewEllipse(3, 2, 5, 2, rotation)
+		sin, cos := math.Sincos(rotation)
+		sampled := polygonOf(4096, func(a float64) vector2.Vector2 {
+			x, y := 5*math.Cos(a), 2*math.Sin(a)
+			return vector2.Vector2{X: 3 + x*cos - y*sin, Y: 2 + x*sin + y*cos}
+		})
+		checkProperties(t, "ellipse", e.Properties(), sampled.Properties(), 1e-5)
+	}
+}
+
+func TestArcProperties(t *testing.T) {
+	const r = 3.0
+	// A quarter disc has its centroid 4r/3π from both radii.
+	quarter := NewArc(0, 0, r, 0, math.Pi/2).Properties(ArcSector)
+	if d := 4 * r / (3 * math.Pi); !quarter.Centroid.IsEqualApprox(vector2.Vector2{X: d, Y: d}) {
+		t.Errorf("quarter disc centroid = %v, want (%g, %g)", quarter.Centroid, d, d)
+	}
+	if want := math.Pi * r * r / 4; math.Abs(quarter.Area-want) > 1e-12 {
+		t.Errorf("quarter disc area = %g, want %g", quarter.Area, want)
+	}
+	if want := math.Pi*r/2 + 2*r; math.Abs(quarter.Perimeter-want) > 1e-12 {
+		t.Errorf("quarter disc perimeter = %g, want %g", quarter.Perimeter, want)
+	}
+
+	// The segment of a half circle is the half disc, with the chord as its
+	// straight side.
+	half := NewArc(0, 0, r, 0, math.Pi)
+	sector, segment := half.Properties(ArcSector), half.Properties(ArcSegment)
+	checkProperties(t, "half disc", segment, sector, 1e-12)
+
+	for _, arc := range []Arc{NewArc(1, 2, r, 0.3, 2.2), NewArc(1, 2, r, 2.2, 0.3)} {
+		points := Polygon{arc.Circle.Center}
+		for k := 0; k <= 4096; k++ {
+			points = append(points, arc.PointAt(arc.AngleStart+arc.Sweep()*float64(k)/4096))
+		}
+		checkProperties(t, "sector", arc.Properties(ArcSector), points.Properties(), 1e-5)
+		checkProperties(t, "segment", arc.Properties(ArcSegment), points[1:].Properties(), 1e-5)
+	}
+
+	full := NewArc(0, 0, r, 0, 2*math.Pi).Properties(ArcSegment)
+	checkProperties(t, "full turn", full, NewCircle(0, 0, r).Properties(), 1e-12)
+}
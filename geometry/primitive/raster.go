@@ -0,0 +1,227 @@
+package primitive
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// RasterOptions configures a raster (zig-zag) pocket.
+type RasterOptions struct {
+	// Angle of the scan lines in radians, measured from the x axis.
+	Angle float64
+	// Finish adds a contour pass along the pocket wall after the raster.
+	Finish bool
+}
+
+// scanSegment is a span of a scan line that lies inside the region.
+type scanSegment struct {
+	line   int
+	x0, x1 float64
+	used   bool
+}
+
+// PocketRaster clears the mesh region with parallel scan lines spaced
+// stepover apart. The passes are placed with the region at Position.
+func (m *Mesh) PocketRaster(toolDiameter, stepover float64, opts RasterOptions) Pocket {
+	return RasterPocketPolygons(m.placedRings(), toolDiameter, stepover, opts)
+}
+
+// RasterPocketPolygons generates a raster pocket for a set of rings. Holes are
+// respected with the even-odd rule. Neighbouring passes are joined into
+// zig-zags when the connecting move stays inside the region; otherwise the
+// tool retracts and a new path is started. With opts.Finish the wall contours
+// are returned as depth 0 loops and cut last.
+func RasterPocketPolygons(rings []Polygon, toolDiameter, stepover float64, opts RasterOptions) Pocket {
+	pocket := Pocket{}
+	if toolDiameter <= 0 || stepover <= 0 {
+		return pocket
+	}
+
+	offsetOpts := DefaultOffsetOptions()
+	offsetOpts.Join = JoinRound
+	offsetOpts.ArcTolerance = toolDiameter * 0.001
+	region := OffsetPolygons(rings, -toolDiameter/2, offsetOpts)
+	if len(region) == 0 {
+		return pocket
+	}
+
+	// Work in a frame where the scan lines are horizontal.
+	rotated := make([]Polygon, len(region))
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for i, ring := range region {
+		rotated[i] = make(Polygon, len(ring))
+		for j, v := range ring {
+			rv := rotate(v, -opts.Angle)
+			rotated[i][j] = rv
+			minY = math.Min(minY, rv.Y)
+			maxY = math.Max(maxY, rv.Y)
+		}
+	}
+
+	lines := int(math.Ceil((maxY - minY) / stepover))
+	if lines < 1 {
+		lines = 1
+	}
+	spacing := (maxY - minY) / float64(lines)
+	rows := make([][]*scanSegment, lines)
+	for k := range rows {
+		y := minY + (float64(k)+0.5)*spacing
+		xs := scanIntersections(rotated, y)
+		for i := 0; i+1 < len(xs); i += 2 {
+			rows[k] = append(rows[k], &scanSegment{line: k, x0: xs[i], x1: xs[i+1]})
+		}
+	}
+
+	lineY := func(k int) float64 { return minY + (float64(k)+0.5)*spacing }
+	for k := range rows {
+		for _, seg := range rows[k] {
+			if seg.used {
+				continue
+			}
+			var points []vector2.Vector2
+			forward := true
+			for cur := seg; cur != nil; {
+				cur.used = true
+				a := vector2.New(cur.x0, lineY(cur.line))
+				b := vector2.New(cur.x1, lineY(cur.line))
+				if !forward {
+					a, b = b, a
+				}
+				points = append(points, a, b)
+				forward = !forward
+				cur = nextRasterSegment(rotated, rows, cur.line+1, b, lineY, forward)
+			}
+			for i, p := range points {
+				points[i] = rotate(p, opts.Angle)
+			}
+			pocket.Paths = append(pocket.Paths, PocketPath{Points: points})
+		}
+	}
+
+	if opts.Finish {
+		for _, ring := range region {
+			pocket.Loops = append(pocket.Loops, PocketLoop{Path: ring, Parent: -1, Order: len(pocket.Loops)})
+			points := append(Polygon{}, ring...)
+			points = append(points, ring[0])
+			pocket.Paths = append(pocket.Paths, PocketPath{Points: points, Loops: []int{len(pocket.Loops) - 1}})
+		}
+	}
+	return pocket
+}
+
+// nextRasterSegment picks the unused segment on the given line whose near end
+// can be reached from the point without leaving the region.
+func nextRasterSegment(rings []Polygon, rows [][]*scanSegment, line int, from vector2.Vector2, lineY func(int) float64, forward bool) *scanSegment {
+	if line >= len(rows) {
+		return nil
+	}
+	var best *scanSegment
+	bestDist := math.Inf(1)
+	for _, seg := range rows[line] {
+		if seg.used {
+			continue
+		}
+		x := seg.x0
+		if !forward {
+			x = seg.x1
+		}
+		to := vector2.New(x, lineY(line))
+		d := from.DistanceSquaredTo(to)
+		if d < bestDist && linkInside(rings, from, to) {
+			bestDist = d
+			best = seg
+		}
+	}
+	return best
+}
+
+// scanIntersections returns the sorted x coordinates where the horizontal line
+// at y crosses the ring edges.
+func scanIntersections(rings []Polygon, y float64) []float64 {
+	var xs []float64
+	for _, ring := range rings {
+		n := len(ring)
+		for i := 0; i < n; i++ {
+			a, b := ring[i], ring[(i+1)%n]
+			if (a.Y <= y) != (b.Y <= y) {
+				t := (y - a.Y) / (b.Y - a.Y)
+				xs = append(xs, a.X+t*(b.X-a.X))
+			}
+		}
+	}
+	sort.Float64s(xs)
+	return xs
+}
+
+// linkInside reports whether the straight move between two boundary points
+// stays inside the region described by rings.
+func linkInside(rings []Polygon, from, to vector2.Vector2) bool {
+	mid := from.Add(to).Mulf(0.5)
+	if !pointInRings(rings, mid) && !pointOnRings(rings, mid, 1e-9*(1+from.DistanceTo(to))) {
+		return false
+	}
+	for _, ring := range rings {
+		n := len(ring)
+		for i := 0; i < n; i++ {
+			if segmentsCross(from, to, ring[i], ring[(i+1)%n]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// pointInRings tests a point against a set of rings with the even-odd rule.
+func pointInRings(rings []Polygon, p vector2.Vector2) bool {
+	inside := false
+	for _, ring := range rings {
+		n := len(ring)
+		for i, j := 0, n-1; i < n; j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+			if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// pointOnRings reports whether a point lies within tolerance of a ring edge.
+func pointOnRings(rings []Polygon, p vector2.Vector2, tolerance float64) bool {
+	for _, ring := range rings {
+		if ringDistance(ring, p) <= tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentsCross reports whether segments ab and cd properly cross, ignoring
+// contacts at their end points and round-off on collinear touches.
+func segmentsCross(a, b, c, d vector2.Vector2) bool {
+	eps := 1e-9 * b.Sub(a).Length() * d.Sub(c).Length()
+	d1 := orientSign(b.Sub(a).Cross(c.Sub(a)), eps)
+	d2 := orientSign(b.Sub(a).Cross(d.Sub(a)), eps)
+	d3 := orientSign(d.Sub(c).Cross(a.Sub(c)), eps)
+	d4 := orientSign(d.Sub(c).Cross(b.Sub(c)), eps)
+	return d1*d2 < 0 && d3*d4 < 0
+}
+
+func orientSign(v, eps float64) int {
+	if v > eps {
+		return 1
+	}
+	if v < -eps {
+		return -1
+	}
+	return 0
+}
+
+// rotate rotates v counter-clockwise by angle radians. vector2.Rotated reuses
+// the updated x component when computing y, so it is not used.
+func rotate(v vector2.Vector2, angle float64) vector2.Vector2 {
+	sin, cos := math.Sincos(angle)
+	return vector2.Vector2{X: v.X*cos - v.Y*sin, Y: v.X*sin + v.Y*cos}
+}
@@ -0,0 +1,94 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// squareDistance returns the distance from p to the filled square
+// [x, x+size]², zero inside it.
+func squareDistance(p vector2.Vector2, x, y, size float64) float64 {
+	dx := math.Max(math.Max(x-p.X, p.X-x-size), 0)
+	dy := math.Max(math.Max(y-p.Y, p.Y-y-size), 0)
+	return math.Hypot(dx, dy)
+}
+
+func TestRasterPocketStaysInOffsetRegion(t *testing.T) {
+	const tool, stepover = 6.0, 2.5
+	island := square(40, 40, 20)
+	reverse(island)
+	rings := []Polygon{square(0, 0, 100), island}
+
+	// The tool center may reach the square [3, 97]² less the island grown
+	// by the tool radius.
+	inside := func(p vector2.Vector2) bool {
+		const eps = 1e-6
+		return p.X >= 3-eps && p.X <= 97+eps && p.Y >= 3-eps && p.Y <= 97+eps &&
+			squareDistance(p, 40, 40, 20) >= 3-0.01
+	}
+
+	for _, angle := range []float64{0, math.Pi / 6, math.Pi / 2} {
+		pocket := RasterPocketPolygons(rings, tool, stepover, RasterOptions{Angle: angle})
+		if len(pocket.Paths) < 2 {
+			t.Fatalf("angle %g: got %d paths, want the island to split the raster", angle, len(pocket.Paths))
+		}
+		var passes [][2]vector2.Vector2
+		for _, path := range pocket.Paths {
+			for i := 0; i+1 < len(path.Points); i++ {
+				a, b := path.Points[i], path.Points[i+1]
+				passes = append(passes, [2]vector2.Vector2{a, b})
+				for k := 0; k <= 20; k++ {
+					if p := a.Add(b.Sub(a).Mulf(float64(k) / 20)); !inside(p) {
+						t.Fatalf("angle %g: move from %v to %v leaves the region at %v", angle, a, b, p)
+					}
+				}
+			}
+		}
+
+		// Every point well inside the region lies within half a stepover of
+		// a pass.
+		for x := 6.0; x <= 94; x += 1.7 {
+			for y := 6.0; y <= 94; y += 1.7 {
+				p := vector2.Vector2{X: x, Y: y}
+				if squareDistance(p, 40, 40, 20) < 6 {
+					continue
+				}
+				best := math.Inf(1)
+				for _, pass := range passes {
+					best = math.Min(best, polylineDistance(Polygon{pass[0], pass[1]}, p))
+				}
+				if best > stepover/2+1e-6 {
+					t.Fatalf("angle %g: %v is %g from the nearest pass", angle, p, best)
+				}
+			}
+		}
+	}
+}
+
+func TestRasterPocketFinish(t *testing.T) {
+	rings := []Polygon{square(0, 0, 40)}
+	plain := RasterPocketPolygons(rings, 4, 2, RasterOptions{})
+	if len(plain.Paths) != 1 || len(plain.Loops) != 0 {
+		t.Fatalf("got %d paths and %d loops, want one zig-zag and no loops", len(plain.Paths), len(plain.Loops))
+	}
+
+	finished := RasterPocketPolygons(rings, 4, 2, RasterOptions{Finish: true})
+	if len(finished.Loops) != 1 || len(finished.Paths) != 2 {
+		t.Fatalf("got %d paths and %d loops, want the zig-zag and one wall pass", len(finished.Paths), len(finished.Loops))
+	}
+	wall := finished.Paths[1].Points
+	if !wall[0].IsEqualApprox(wall[len(wall)-1]) {
+		t.Error("the wall pass is not closed")
+	}
+	if got := totalArea([]Polygon{finished.Loops[0].Path}); math.Abs(got-36*36) > 1e-6 {
+		t.Errorf("the wall pass encloses %g, want %g", got, 36.0*36)
+	}
+
+	for _, tt := range []struct{ tool, stepover float64 }{{0, 1}, {4, 0}, {50, 1}} {
+		if got := RasterPocketPolygons(rings, tt.tool, tt.stepover, RasterOptions{}); len(got.Paths) != 0 {
+			t.Errorf("tool %g, stepover %g: got %d paths, want none", tt.tool, tt.stepover, len(got.Paths))
+		}
+	}
+}
@@ -30,7 +30,8 @@ func (r Rectangle) Scale(factor float64) Shape {
 }
 
 func (r Rectangle) GetBoundingBox() rect2.Rect2 {
-	return rect2.New(r.Offset, r.Size)
+	rect := rect2.New(r.Offset, r.Size)
+	return rect.ABS()
 }
 
 func (r Rectangle) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
@@ -60,10 +61,9 @@ func (r Rectangle) DrawFilled(dc *gg.Context, color [4]float64) {
 	dc.Pop()
 }
 
-func (r Rectangle) SignedDistance(x, y int) float64 {
+func (r Rectangle) SignedDistance(p vector2.Vector2) float64 {
 	// Calculate the position of the point relative to the rectangle center.
 	// Assuming r.Center is the center of the rectangle.
-	p := vector2.Vector2{X: float64(x), Y: float64(y)}
 	q := p.Sub(r.Offset.Add(r.Size.Mulf(0.5))).ABS().Sub(r.Size.Mulf(0.5)) // Subtract half-extents (rectangle size / 2)
 
 	// Calculate the distance to the rectangle.
@@ -73,3 +73,11 @@ func (r Rectangle) SignedDistance(x, y int) float64 {
 	// Return the total signed distance.
 	return outsideDist + insideDist
 }
+
+// SampleDistance returns the signed distance with the nearest point of the
+// rectangle's edges.
+func (r Rectangle) SampleDistance(p vector2.Vector2) DistanceSample {
+	return ringsSample([]Polygon{Polygonize(r, 0)}, p, func(q vector2.Vector2) bool {
+		return r.SignedDistance(q) < 0
+	})
+}
@@ -0,0 +1,265 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/fogleman/gg"
+)
+
+// FillRule decides which points are inside a set of overlapping rings.
+type FillRule int
+
+const (
+	// NonZero fills points with a nonzero winding number.
+	NonZero FillRule = iota
+	// EvenOdd fills points enclosed by an odd number of rings.
+	EvenOdd
+)
+
+// Region is an area bounded by any number of rings, such as a polygon with
+// holes or several disjoint polygons. Regions built by the boolean and offset
+// operations are normalized: outer rings have a positive signed area, holes a
+// negative one, and no two rings cross, so both fill rules agree.
+type Region struct {
+	Rings []Polygon
+	Rule  FillRule
+}
+
+// NewRegion returns a region of the rings as given, filled with rule.
+func NewRegion(rule FillRule, rings ...Polygon) Region {
+	return Region{Rings: rings, Rule: rule}
+}
+
+// RegionFromShape returns a region covering the shape, sampling curved
+// outlines within tolerance.
+func RegionFromShape(s Shape, tolerance float64) Region {
+	if r, ok := s.(Region); ok {
+		return r
+	}
+	return Region{Rings: []Polygon{Polygonize(s, tolerance)}}
+}
+
+// Normalized resolves the rings under the fill rule into non-crossing outer
+// rings and holes with consistent orientation.
+func (r Region) Normalized() Region {
+	return Region{Rings: clipRings(r.Rings, r.Rule, nil, NonZero, Union)}
+}
+
+// Outers returns the rings with a positive signed area.
+func (r Region) Outers() []Polygon {
+	var rings []Polygon
+	for _, ring := range r.Rings {
+		if signedArea(ring) > 0 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// Holes returns the rings with a negative signed area.
+func (r Region) Holes() []Polygon {
+	var rings []Polygon
+	for _, ring := range r.Rings {
+		if signedArea(ring) < 0 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// IsEmpty reports whether the region has no rings that enclose an area.
+func (r Region) IsEmpty() bool {
+	for _, ring := range r.Rings {
+		if len(ring) > 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the area covered by either region.
+func (r Region) Union(other Region) Region {
+	return ClipRegions(r, other, Union)
+}
+
+// Difference returns r with other removed from it.
+func (r Region) Difference(other Region) Region {
+	return ClipRegions(r, other, Difference)
+}
+
+// Intersection returns the area shared by both regions.
+func (r Region) Intersection(other Region) Region {
+	return ClipRegions(r, other, Intersection)
+}
+
+// Xor returns the area covered by exactly one of the regions.
+func (r Region) Xor(other Region) Region {
+	return ClipRegions(r, other, Xor)
+}
+
+// Offset grows the region by delta, or shrinks it when delta is negative.
+// Holes shrink as the outer rings grow.
+func (r Region) Offset(delta float64, opts OffsetOptions) Region {
+	return Region{Rings: OffsetPolygons(r.Normalized().Rings, delta, opts)}
+}
+
+// fills reports whether the point is inside the region under its fill rule.
+func (r Region) fills(p vector2.Vector2) bool {
+	winding := 0
+	for _, ring := range r.Rings {
+		winding += windingNumber(ring, p)
+	}
+	return insideWinding(winding, r.Rule)
+}
+
+// insideWinding applies a fill rule to a winding number.
+func insideWinding(winding int, fill FillRule) bool {
+	if fill == EvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// windingNumber counts how many times the ring winds counter-clockwise
+// around p.
+func windingNumber(ring Polygon, p vector2.Vector2) int {
+	winding := 0
+	n := len(ring)
+	for i := 0; i < n; i++ {
+		winding += edgeWinding(ring[i], ring[(i+1)%n], p)
+	}
+	return winding
+}
+
+// edgeWinding returns +1 when edge ab crosses the rightward ray from p going
+// up, -1 when it crosses going down, and 0 otherwise.
+func edgeWinding(a, b, p vector2.Vector2) int {
+	cross := (b.X-a.X)*(p.Y-a.Y) - (p.X-a.X)*(b.Y-a.Y)
+	if a.Y <= p.Y {
+		if b.Y > p.Y && cross > 0 {
+			return 1
+		}
+	} else if b.Y <= p.Y && cross < 0 {
+		return -1
+	}
+	return 0
+}
+
+func (r Region) Translate(offsetX, offsetY float64) Shape {
+	rings := make([]Polygon, len(r.Rings))
+	for i, ring := range r.Rings {
+		rings[i] = make(Polygon, len(ring))
+		for j, v := range ring {
+			rings[i][j] = v.Add(vector2.Vector2{X: offsetX, Y: offsetY})
+		}
+	}
+	r.Rings = rings
+	return r
+}
+
+func (r Region) Scale(factor float64) Shape {
+	rings := make([]Polygon, len(r.Rings))
+	for i, ring := range r.Rings {
+		rings[i] = make(Polygon, len(ring))
+		for j, v := range ring {
+			rings[i][j] = v.Mulf(factor)
+		}
+	}
+	r.Rings = rings
+	return r
+}
+
+func (r Region) GetBoundingBox() rect2.Rect2 {
+	var rect rect2.Rect2
+	found := false
+	for _, ring := range r.Rings {
+		if len(ring) == 0 {
+			continue
+		}
+		if !found {
+			rect = ring.GetBoundingBox()
+			found = true
+		} else {
+			rect = rect.Merge(ring.GetBoundingBox())
+		}
+	}
+	return rect
+}
+
+func (r Region) Draw(dc *gg.Context, color [4]float64, lwidth float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	r.path(dc)
+	dc.Stroke()
+	dc.Pop()
+}
+
+func (r Region) DrawDashed(dc *gg.Context, color [4]float64, lwidth, dashLength, dashInterval float64) {
+	dc.Push()
+	dc.SetLineWidth(lwidth)
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	r.path(dc)
+	dc.SetDash(dashLength, dashInterval)
+	dc.Stroke()
+	dc.Pop()
+}
+
+// DrawFilled fills the region with its fill rule, leaving holes open.
+func (r Region) DrawFilled(dc *gg.Context, color [4]float64) {
+	dc.Push()
+	dc.SetRGBA(color[0], color[1], color[2], color[3])
+	if r.Rule == EvenOdd {
+		dc.SetFillRuleEvenOdd()
+	} else {
+		dc.SetFillRuleWinding()
+	}
+	r.path(dc)
+	dc.Fill()
+	dc.Pop()
+}
+
+func (r Region) path(dc *gg.Context) {
+	for _, ring := range r.Rings {
+		if len(ring) == 0 {
+			continue
+		}
+		dc.MoveTo(ring[0].X, ring[0].Y)
+		for _, v := range ring[1:] {
+			dc.LineTo(v.X, v.Y)
+		}
+		dc.ClosePath()
+	}
+}
+
+// SignedDistance returns the distance to the nearest ring edge, negative
+// inside the region.
+func (r Region) SignedDistance(p vector2.Vector2) float64 {
+	d := math.Inf(1)
+	for _, ring := range r.Rings {
+		if len(ring) > 0 {
+			d = math.Min(d, ringDistance(ring, p))
+		}
+	}
+	if r.fills(p) {
+		return -d
+	}
+	return d
+}
+
+// SampleDistance returns the signed distance with the nearest point of the
+// region's rings, as SignedDistance.
+func (r Region) SampleDistance(p vector2.Vector2) DistanceSample {
+	return ringsSample(r.Rings, p, r.fills)
+}
+
+// Area returns the filled area of a normalized region.
+func (r Region) Area() float64 {
+	area := 0.0
+	for _, ring := range r.Rings {
+		area += signedArea(ring)
+	}
+	return area
+}
@@ -0,0 +1,321 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/rect2"
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// SDF is a node of a signed distance field tree. Every Shape is a leaf, and
+// the node types below combine or reshape other nodes. The bounding box
+// holds every point where the distance is not positive, so a tree can be
+// traced with Contour.
+type SDF interface {
+	SignedDistance(p vector2.Vector2) float64
+	GetBoundingBox() rect2.Rect2
+}
+
+// Contour traces the z level set of the tree, as BooleanGroup.GetContour
+// does for a group, over the tree's bounds grown to leave room for it.
+func Contour(f SDF, z, cellSize float64) Region {
+	return traceContour(f.GetBoundingBox(), z, cellSize, f.SignedDistance)
+}
+
+// SmoothUnion joins two fields, blending them where they are within Radius
+// of each other into a fillet. A radius of zero gives the plain union.
+type SmoothUnion struct {
+	A, B   SDF
+	Radius float64
+}
+
+func NewSmoothUnion(a, b SDF, radius float64) SmoothUnion {
+	return SmoothUnion{A: a, B: b, Radius: radius}
+}
+
+func (u SmoothUnion) SignedDistance(p vector2.Vector2) float64 {
+	return smoothMin(u.A.SignedDistance(p), u.B.SignedDistance(p), u.Radius)
+}
+
+// GetBoundingBox returns the merged bounds of both fields, grown by the
+// most the blend can add.
+func (u SmoothUnion) GetBoundingBox() rect2.Rect2 {
+	a := u.A.GetBoundingBox()
+	return a.Merge(u.B.GetBoundingBox()).Grow(math.Max(u.Radius, 0) / 4)
+}
+
+// SmoothIntersection keeps the overlap of two fields, rounding the corners
+// where they cross with a blend of the given radius.
+type SmoothIntersection struct {
+	A, B   SDF
+	Radius float64
+}
+
+func NewSmoothIntersection(a, b SDF, radius float64) SmoothIntersection {
+	return SmoothIntersection{A: a, B: b, Radius: radius}
+}
+
+func (i SmoothIntersection) SignedDistance(p vector2.Vector2) float64 {
+	return -smoothMin(-i.A.SignedDistance(p), -i.B.SignedDistance(p), i.Radius)
+}
+
+// GetBoundingBox returns the overlap of both fields' bounds, which is empty
+// at the corner where A's bounds start when they do not overlap.
+func (i SmoothIntersection) GetBoundingBox() rect2.Rect2 {
+	a, b := i.A.GetBoundingBox(), i.B.GetBoundingBox()
+	start := a.Position.Max(b.Position)
+	end := a.Position.Add(a.Size).Min(b.Position.Add(b.Size))
+	if end.X < start.X || end.Y < start.Y {
+		return rect2.Rect2{Position: a.Position}
+	}
+	return rect2.Rect2{Position: start, Size: end.Sub(start)}
+}
+
+// SmoothDifference removes B from A, rounding the corners where they cross
+// with a blend of the given radius.
+type SmoothDifference struct {
+	A, B   SDF
+	Radius float64
+}
+
+func NewSmoothDifference(a, b SDF, radius float64) SmoothDifference {
+	return SmoothDifference{A: a, B: b, Radius: radius}
+}
+
+func (d SmoothDifference) SignedDistance(p vector2.Vector2) float64 {
+	return -smoothMin(-d.A.SignedDistance(p), d.B.SignedDistance(p), d.Radius)
+}
+
+func (d SmoothDifference) GetBoundingBox() rect2.Rect2 {
+	return d.A.GetBoundingBox()
+}
+
+// smoothMin returns the minimum of a and b, lowered by up to radius/4 where
+// they are within radius of each other so that the result stays smooth.
+func smoothMin(a, b, radius float64) float64 {
+	if radius <= 0 {
+		return math.Min(a, b)
+	}
+	h := math.Max(radius-math.Abs(a-b), 0) / radius
+	return math.Min(a, b) - h*h*radius/4
+}
+
+// Rounded grows a field by Radius, which rounds its corners. Rounding a line
+// or a curve gives a capsule around it.
+type Rounded struct {
+	Child  SDF
+	Radius float64
+}
+
+func NewRounded(child SDF, radius float64) Rounded {
+	return Rounded{Child: child, Radius: radius}
+}
+
+func (r Rounded) SignedDistance(p vector2.Vector2) float64 {
+	return r.Child.SignedDistance(p) - r.Radius
+}
+
+func (r Rounded) GetBoundingBox() rect2.Rect2 {
+	return r.Child.GetBoundingBox().Grow(math.Max(r.Radius, 0))
+}
+
+// Shell hollows a field into a band reaching Thickness to either side of its
+// outline. Shells of shells give concentric onion rings.
+type Shell struct {
+	Child     SDF
+	Thickness float64
+}
+
+func NewShell(child SDF, thickness float64) Shell {
+	return Shell{Child: child, Thickness: thickness}
+}
+
+func (s Shell) SignedDistance(p vector2.Vector2) float64 {
+	return math.Abs(s.Child.SignedDistance(p)) - s.Thickness
+}
+
+func (s Shell) GetBoundingBox() rect2.Rect2 {
+	return s.Child.GetBoundingBox().Grow(math.Max(s.Thickness, 0))
+}
+
+// Repeat copies a field on a grid: Count[0] copies Spacing.X apart along X
+// by Count[1] copies Spacing.Y apart along Y, starting from the child
+// itself. Counts below one mean a single copy. The distance is exact while
+// each copy only reaches into the cells of its neighbours.
+type Repeat struct {
+	Child   SDF
+	Spacing vector2.Vector2
+	Count   [2]int
+}
+
+func NewRepeat(child SDF, spacing vector2.Vector2, countX, countY int) Repeat {
+	return Repeat{Child: child, Spacing: spacing, Count: [2]int{countX, countY}}
+}
+
+func (r Repeat) SignedDistance(p vector2.Vector2) float64 {
+	bounds := r.Child.GetBoundingBox()
+	center := bounds.Position.Add(bounds.Size.Mulf(0.5))
+	xs := repeatCells(p.X-center.X, r.Spacing.X, r.Count[0])
+	ys := repeatCells(p.Y-center.Y, r.Spacing.Y, r.Count[1])
+	d := math.Inf(1)
+	for _, i := range xs {
+		for _, j := range ys {
+			q := vector2.Vector2{X: p.X - float64(i)*r.Spacing.X, Y: p.Y - float64(j)*r.Spacing.Y}
+			d = math.Min(d, r.Child.SignedDistance(q))
+		}
+	}
+	return d
+}
+
+// repeatCells returns the index of the copy nearest to offset along one
+// axis, and of its neighbour on the side of offset when there is one.
+func repeatCells(offset, spacing float64, count int) []int {
+	if spacing == 0 || count <= 1 {
+		return []int{0}
+	}
+	cell := offset / spacing
+	nearest := min(max(int(math.Round(cell)), 0), count-1)
+	next := nearest + 1
+	if cell < float64(nearest) {
+		next = nearest - 1
+	}
+	if next < 0 || next >= count {
+		return []int{nearest}
+	}
+	return []int{nearest, next}
+}
+
+func (r Repeat) GetBoundingBox() rect2.Rect2 {
+	bounds := r.Child.GetBoundingBox()
+	last := vector2.Vector2{
+		X: float64(max(r.Count[0]-1, 0)) * r.Spacing.X,
+		Y: float64(max(r.Count[1]-1, 0)) * r.Spacing.Y,
+	}
+	moved := rect2.Rect2{Position: bounds.Position.Add(last), Size: bounds.Size}
+	return bounds.Merge(moved)
+}
+
+// Mirror reflects a field across the line through Origin perpendicular to
+// Normal. The part of the child on the side Normal points to is kept and
+// its mirror image replaces the other side.
+type Mirror struct {
+	Child  SDF
+	Origin vector2.Vector2
+	Normal vector2.Vector2
+}
+
+func NewMirror(child SDF, origin, normal vector2.Vector2) Mirror {
+	return Mirror{Child: child, Origin: origin, Normal: normal}
+}
+
+func (m Mirror) SignedDistance(p vector2.Vector2) float64 {
+	return m.Child.SignedDistance(m.reflect(p, true))
+}
+
+// reflect returns p reflected across the mirror line, or only when it lies
+// behind the line if behindOnly is set.
+func (m Mirror) reflect(p vector2.Vector2, behindOnly bool) vector2.Vector2 {
+	n := m.Normal.Normalized()
+	side := p.Sub(m.Origin).Dot(n)
+	if behindOnly && side >= 0 {
+		return p
+	}
+	return p.Sub(n.Mulf(2 * side))
+}
+
+func (m Mirror) GetBoundingBox() rect2.Rect2 {
+	bounds := m.Child.GetBoundingBox()
+	end := bounds.Position.Add(bounds.Size)
+	for _, corner := range []vector2.Vector2{bounds.Position, end, {X: bounds.Position.X, Y: end.Y}, {X: end.X, Y: bounds.Position.Y}} {
+		bounds = bounds.Expand(m.reflect(corner, false))
+	}
+	return bounds
+}
+
+// Twist swirls a field around Center, turning each point by Rate radians per
+// unit of its distance from the center. Contours are exact, but distances
+// away from the outline are only approximate.
+type Twist struct {
+	Child  SDF
+	Center vector2.Vector2
+	Rate   float64
+}
+
+func NewTwist(child SDF, center vector2.Vector2, rate float64) Twist {
+	return Twist{Child: child, Center: center, Rate: rate}
+}
+
+func (t Twist) SignedDistance(p vector2.Vector2) float64 {
+	offset := p.Sub(t.Center)
+	return t.Child.SignedDistance(t.Center.Add(rotate(offset, -t.Rate*offset.Length())))
+}
+
+// GetBoundingBox returns the square around the center holding every turn of
+// the child's bounds.
+func (t Twist) GetBoundingBox() rect2.Rect2 {
+	bounds := t.Child.GetBoundingBox()
+	end := bounds.Position.Add(bounds.Size)
+	radius := 0.0
+	for _, corner := range []vector2.Vector2{bounds.Position, end, {X: bounds.Position.X, Y: end.Y}, {X: end.X, Y: bounds.Position.Y}} {
+		radius = math.Max(radius, corner.DistanceTo(t.Center))
+	}
+	return rect2.Rect2{Position: t.Center}.Grow(radius)
+}
+
+// Bend wraps the X axis of a field around a circle of radius 1/Curvature
+// that touches the axis at the origin, bending towards +Y for positive
+// curvature. Distances across the axis are kept; along it they scale with
+// the distance from the circle's center, so only contours are exact. The
+// child should lie within half a circumference of the origin along X.
+type Bend struct {
+	Child     SDF
+	Curvature float64
+}
+
+func NewBend(child SDF, curvature float64) Bend {
+	return Bend{Child: child, Curvature: curvature}
+}
+
+func (b Bend) SignedDistance(p vector2.Vector2) float64 {
+	if b.Curvature == 0 {
+		return b.Child.SignedDistance(p)
+	}
+	// The child's point (x, y) lands at center + (R - y)(sin kx, -cos kx).
+	radius := 1 / b.Curvature
+	v := p.Sub(vector2.Vector2{Y: radius})
+	sign := math.Copysign(1, radius)
+	angle := math.Atan2(v.X*sign, -v.Y*sign)
+	return b.Child.SignedDistance(vector2.Vector2{X: angle * radius, Y: radius - sign*v.Length()})
+}
+
+// GetBoundingBox returns the bounds of the annular sector the child's bounds
+// bend into.
+func (b Bend) GetBoundingBox() rect2.Rect2 {
+	bounds := b.Child.GetBoundingBox()
+	if b.Curvature == 0 {
+		return bounds
+	}
+	radius := 1 / b.Curvature
+	center := vector2.Vector2{Y: radius}
+	limit := math.Pi * math.Abs(radius)
+	x0 := math.Max(bounds.Position.X, -limit)
+	x1 := math.Min(bounds.Position.X+bounds.Size.X, limit)
+	var result rect2.Rect2
+	for i, y := range []float64{bounds.Position.Y, bounds.Position.Y + bounds.Size.Y} {
+		rho := radius - y
+		offset := -math.Pi / 2
+		if rho < 0 {
+			offset += math.Pi
+		}
+		arc := NewArc(center.X, center.Y, math.Abs(rho), x0*b.Curvature+offset, x1*b.Curvature+offset)
+		if i == 0 {
+			result = arc.GetBoundingBox()
+		} else {
+			result = result.Merge(arc.GetBoundingBox())
+		}
+	}
+	if y0, y1 := bounds.Position.Y, bounds.Position.Y+bounds.Size.Y; y0 <= radius && radius <= y1 {
+		result = result.Expand(center)
+	}
+	return result
+}
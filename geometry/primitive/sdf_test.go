@@ -0,0 +1,198 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+// checkBounds tests on a grid around the bounds that every point where the
+// field is not positive lies inside them.
+func checkBounds(t *testing.T, name string, f SDF) {
+	t.Helper()
+	box := f.GetBoundingBox()
+	grown := box.Grow(5)
+	for i := 0; i <= 100; i++ {
+		for j := 0; j <= 100; j++ {
+			p := vector2.Vector2{
+				X: grown.Position.X + grown.Size.X*float64(i)/100,
+				Y: grown.Position.Y + grown.Size.Y*float64(j)/100,
+			}
+			if f.SignedDistance(p) > 0 {
+				continue
+			}
+			if p.X < box.Position.X-1e-9 || p.X > box.Position.X+box.Size.X+1e-9 ||
+				p.Y < box.Position.Y-1e-9 || p.Y > box.Position.Y+box.Size.Y+1e-9 {
+				t.Fatalf("%s: inside point %v lies outside the bounds %v", name, p, box)
+			}
+		}
+	}
+}
+
+func TestSmoothBooleans(t *testing.T) {
+	a, b := NewCircle(0, 0, 2), NewCircle(3, 0, 2)
+	const radius = 1.0
+	for _, tt := range []struct {
+		name  string
+		f     SDF
+		plain func(p vector2.Vector2) float64
+		sign  float64
+	}{
+		{"union", NewSmoothUnion(a, b, radius), func(p vector2.Vector2) float64 {
+			return math.Min(a.SignedDistance(p), b.SignedDistance(p))
+		}, -1},
+		{"intersection", NewSmoothIntersection(a, b, radius), func(p vector2.Vector2) float64 {
+			return math.Max(a.SignedDistance(p), b.SignedDistance(p))
+		}, 1},
+		{"difference", NewSmoothDifference(a, b, radius), func(p vector2.Vector2) float64 {
+			return math.Max(a.SignedDistance(p), -b.SignedDistance(p))
+		}, 1},
+	} {
+		for i := -10; i <= 10; i++ {
+			for j := -10; j <= 10; j++ {
+				p := vector2.Vector2{X: 1.5 + float64(i)*0.4, Y: float64(j) * 0.4}
+				got, plain := tt.f.SignedDistance(p), tt.plain(p)
+				// The blend moves the plain result by at most radius/4, and
+				// only towards the side that fills the corner.
+				if d := (got - plain) * tt.sign; d < -1e-12 || d > radius/4+1e-12 {
+					t.Fatalf("%s at %v: got %g, plain %g", tt.name, p, got, plain)
+				}
+			}
+		}
+		checkBounds(t, tt.name, tt.f)
+	}
+
+	// Far from the crossing the blend leaves the distance alone, and a
+	// radius of zero gives the plain union.
+	p := vector2.Vector2{X: -5, Y: 0}
+	if got := NewSmoothUnion(a, b, radius).SignedDistance(p); got != 3 {
+		t.Errorf("union far from the blend = %g, want 3", got)
+	}
+	q := vector2.Vector2{X: 1.5, Y: 1}
+	if got, want := NewSmoothUnion(a, b, 0).SignedDistance(q), math.Min(a.SignedDistance(q), b.SignedDistance(q)); got != want {
+		t.Errorf("union with no radius = %g, want %g", got, want)
+	}
+	// Midway between equal fields the blend lowers them by the full radius/4.
+	if got, want := NewSmoothUnion(a, b, radius).SignedDistance(q), a.SignedDistance(q)-radius/4; math.Abs(got-want) > 1e-12 {
+		t.Errorf("union midway = %g, want %g", got, want)
+	}
+}
+
+func TestRoundedAndShell(t *testing.T) {
+	rounded := NewRounded(square(0, 0, 4), 1)
+	for _, tt := range []struct {
+		p    vector2.Vector2
+		want float64
+	}{
+		{vector2.Vector2{X: 2, Y: 2}, -3},
+		{vector2.Vector2{X: 6, Y: 2}, 1},
+		{vector2.Vector2{X: 5, Y: 5}, math.Sqrt2 - 1},
+	} {
+		if got := rounded.SignedDistance(tt.p); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("rounded at %v = %g, want %g", tt.p, got, tt.want)
+		}
+	}
+	checkBounds(t, "rounded", rounded)
+	if got, want := totalArea(Contour(rounded, 0, 0.05).Rings), 16+16+math.Pi; math.Abs(got-want) > 0.05 {
+		t.Errorf("rounded square contour has area %g, want %g", got, want)
+	}
+
+	shell := NewShell(NewCircle(0, 0, 5), 1)
+	for _, tt := range []struct {
+		p    vector2.Vector2
+		want float64
+	}{
+		{vector2.Vector2{}, 4},
+		{vector2.Vector2{X: 5}, -1},
+		{vector2.Vector2{Y: 6.5}, 0.5},
+	} {
+		if got := shell.SignedDistance(tt.p); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("shell at %v = %g, want %g", tt.p, got, tt.want)
+		}
+	}
+	checkBounds(t, "shell", shell)
+	if got, want := totalArea(Contour(shell, 0, 0.05).Rings), math.Pi*(36-16); math.Abs(got-want) > 0.1 {
+		t.Errorf("shell contour has area %g, want %g", got, want)
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	child := NewCircle(0, 0, 1)
+	r := NewRepeat(child, vector2.Vector2{X: 5, Y: 4}, 3, 2)
+	for i := -20; i <= 40; i++ {
+		for j := -20; j <= 30; j++ {
+			p := vector2.Vector2{X: float64(i) * 0.5, Y: float64(j) * 0.5}
+			want := math.Inf(1)
+			for cx := 0; cx < 3; cx++ {
+				for cy := 0; cy < 2; cy++ {
+					want = math.Min(want, p.DistanceTo(vector2.Vector2{X: float64(cx) * 5, Y: float64(cy) * 4})-1)
+				}
+			}
+			if got := r.SignedDistance(p); math.Abs(got-want) > 1e-12 {
+				t.Fatalf("repeat at %v = %g, want %g", p, got, want)
+			}
+		}
+	}
+	checkBounds(t, "repeat", r)
+}
+
+func TestMirror(t *testing.T) {
+	child := NewCircle(3, 1, 1)
+	m := NewMirror(child, vector2.Vector2{X: 1}, vector2.Vector2{X: 2})
+	for _, tt := range []struct {
+		p    vector2.Vector2
+		want float64
+	}{
+		{vector2.Vector2{X: 3, Y: 1}, -1},
+		{vector2.Vector2{X: -1, Y: 1}, -1},
+		{vector2.Vector2{X: -3, Y: 1}, 1},
+		{vector2.Vector2{X: 1, Y: 1}, 1},
+	} {
+		if got := m.SignedDistance(tt.p); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("mirror at %v = %g, want %g", tt.p, got, tt.want)
+		}
+	}
+	checkBounds(t, "mirror", m)
+}
+
+func TestTwistAndBendKeepContours(t *testing.T) {
+	child := NewRectangle(2, -1, 6, 2)
+
+	twist := NewTwist(child, vector2.Vector2{}, 0.3)
+	bend := NewBend(child, 0.2)
+	radius := 1 / bend.Curvature
+	for k := 0; k <= 40; k++ {
+		// Points on the outline of the child.
+		var p vector2.Vector2
+		switch s := float64(k) / 10; {
+		case s < 1:
+			p = vector2.Vector2{X: 2 + 6*s, Y: -1}
+		case s < 2:
+			p = vector2.Vector2{X: 8, Y: -1 + 2*(s-1)}
+		case s < 3:
+			p = vector2.Vector2{X: 8 - 6*(s-2), Y: 1}
+		default:
+			p = vector2.Vector2{X: 2, Y: 1 - 2*(s-3)}
+		}
+
+		twisted := rotate(p, 0.3*p.Length())
+		if got := twist.SignedDistance(twisted); math.Abs(got) > 1e-9 {
+			t.Errorf("twisted outline point %v has distance %g", twisted, got)
+		}
+
+		bent := vector2.Vector2{Y: radius}.Add(vector2.Vector2{X: math.Sin(p.X / radius), Y: -math.Cos(p.X / radius)}.Mulf(radius - p.Y))
+		if got := bend.SignedDistance(bent); math.Abs(got) > 1e-9 {
+			t.Errorf("bent outline point %v has distance %g", bent, got)
+		}
+	}
+	if got := NewTwist(child, vector2.Vector2{}, 0).SignedDistance(vector2.Vector2{X: 5, Y: 3}); got != 2 {
+		t.Errorf("untwisted distance = %g, want 2", got)
+	}
+	if got := NewBend(child, 0).SignedDistance(vector2.Vector2{X: 5, Y: 3}); got != 2 {
+		t.Errorf("unbent distance = %g, want 2", got)
+	}
+	checkBounds(t, "twist", twist)
+	checkBounds(t, "bend", bend)
+	checkBounds(t, "bend backwards", NewBend(child, -0.2))
+}
@@ -0,0 +1,90 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
+)
+
+// Transform maps the circle through an affine transform. Similarity
+// transforms keep it a Circle; any other transform returns an Ellipse.
+func (c Circle) Transform(m algebra.Affine) Shape {
+	if m.IsSimilarity() {
+		center := m.Apply(c.Center)
+		return Circle{Center: center, Radius: c.Radius * m.ScaleFactor()}
+	}
+	return Ellipse{Center: c.Center, Radii: vector2.Vector2{X: c.Radius, Y: c.Radius}}.Transform(m)
+}
+
+// Transform maps the rectangle through an affine transform. Transforms that
+// keep the edges axis aligned return a Rectangle; any other transform
+// returns the mapped corners as a Polygon.
+func (r Rectangle) Transform(m algebra.Affine) Shape {
+	if m.IsAxisAligned() {
+		a, b := m.Apply(r.Offset), m.Apply(r.Offset.Add(r.Size))
+		return NewRectangle(math.Min(a.X, b.X), math.Min(a.Y, b.Y), math.Abs(b.X-a.X), math.Abs(b.Y-a.Y))
+	}
+	return Polygonize(r, 0).Transform(m)
+}
+
+// Transform returns a copy of the polygon with every vertex mapped. Vertex
+// order is kept, so a mirroring transform reverses the winding.
+func (p Polygon) Transform(m algebra.Affine) Shape {
+	out := make(Polygon, len(p))
+	for i, v := range p {
+		out[i] = m.Apply(v)
+	}
+	return out
+}
+
+func (l Line) Transform(m algebra.Affine) Shape {
+	return Line{Start: m.Apply(l.Start), End: m.Apply(l.End)}
+}
+
+// Transform maps the arc through an affine transform. Under a similarity
+// transform the angles are remapped through the rotation, and a mirroring
+// transform reverses the direction of travel. Other transforms turn the arc
+// into an EllipticalArc.
+func (a Arc) Transform(m algebra.Affine) Shape {
+	if !m.IsSimilarity() {
+		return a.elliptical().Transform(m)
+	}
+	start := m.ApplyVector(vector2.Vector2{X: math.Cos(a.AngleStart), Y: math.Sin(a.AngleStart)})
+	angle := math.Atan2(start.Y, start.X)
+	sweep := a.Sweep()
+	if m.Determinant() < 0 {
+		sweep = -sweep
+	}
+	return Arc{
+		Circle:     a.Circle.Transform(m).(Circle),
+		AngleStart: angle,
+		AngleEnd:   angle + sweep,
+	}
+}
+
+// Transform maps every ring. Rings are reversed under a mirroring transform
+// so that outer rings and holes keep their orientation.
+func (r Region) Transform(m algebra.Affine) Shape {
+	rings := make([]Polygon, len(r.Rings))
+	for i, ring := range r.Rings {
+		rings[i] = ring.Transform(m).(Polygon)
+		if m.Determinant() < 0 {
+			reverse(rings[i])
+		}
+	}
+	r.Rings = rings
+	return r
+}
+
+// Transform returns a copy of the mesh with its region mapped.
+func (m Mesh) Transform(t algebra.Affine) Mesh {
+	m.Region = m.Region.Transform(t).(Region)
+	return m
+}
+
+func reverse(p Polygon) {
+	for i, j := 0, len(p)-1; i < j; i, j = i+1, j-1 {
+		p[i], p[j] = p[j], p[i]
+	}
+}
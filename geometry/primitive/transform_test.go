@@ -0,0 +1,34 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/algebra"
+)
+
+func TestArcTransformMirrorKeepsSide(t *testing.T) {
+	r := 10 / math.Sqrt2
+	tests := []struct {
+		name string
+		m    algebra.Affine
+		mid  vector2.Vector2
+	}{
+		{"mirror x", algebra.Scaling(-1, 1), vector2.Vector2{X: -r, Y: r}},
+		{"mirror y", algebra.Scaling(1, -1), vector2.Vector2{X: r, Y: -r}},
+		{"rotate", algebra.Rotation(math.Pi), vector2.Vector2{X: -r, Y: -r}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arc, ok := NewArc(0, 0, 10, 0, math.Pi/2).Transform(tt.m).(Arc)
+			if !ok {
+				t.Fatal("transform did not return an Arc")
+			}
+			points := Polygonize(arc, 0.01)
+			if mid := points[len(points)/2]; mid.DistanceTo(tt.mid) > 1e-9 {
+				t.Errorf("midpoint = %v, want %v", mid, tt.mid)
+			}
+		})
+	}
+}
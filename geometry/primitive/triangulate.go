@@ -0,0 +1,24 @@
+package primitive
+
+import (
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/triangulate"
+)
+
+// Triangulate splits a simple polygon into triangles by ear clipping. The
+// triangles index the polygon's vertices.
+func (p Polygon) Triangulate() ([][3]int, error) {
+	return triangulate.EarClip(p)
+}
+
+// Triangulate returns a constrained Delaunay triangulation of the region.
+// Every ring edge is kept, holes stay empty, and opts can request quality
+// refinement with Steiner points.
+func (r Region) Triangulate(opts triangulate.Options) (triangulate.Triangulation, error) {
+	normalized := r.Normalized()
+	rings := make([][]vector2.Vector2, len(normalized.Rings))
+	for i, ring := range normalized.Rings {
+		rings[i] = ring
+	}
+	return triangulate.Constrained(rings, opts)
+}
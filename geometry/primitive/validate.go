@@ -0,0 +1,266 @@
+package primitive
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/sweep"
+)
+
+// DefectKind classifies a problem found by Validate.
+type DefectKind int
+
+const (
+	// SelfIntersection is a point where edges cross, or where an edge
+	// touches another away from their shared vertex.
+	SelfIntersection DefectKind = iota
+	// DuplicateVertex is a point where vertices that are not neighbours
+	// coincide, pinching the outline.
+	DuplicateVertex
+	// ZeroLengthEdge is an edge shorter than the tolerance.
+	ZeroLengthEdge
+	// WrongOrientation is an outer ring with a negative signed area, or a
+	// hole with a positive one.
+	WrongOrientation
+	// TooFewVertices is a ring with fewer than three distinct vertices.
+	TooFewVertices
+)
+
+func (k DefectKind) String() string {
+	switch k {
+	case SelfIntersection:
+		return "self-intersection"
+	case DuplicateVertex:
+		return "duplicate vertex"
+	case ZeroLengthEdge:
+		return "zero-length edge"
+	case WrongOrientation:
+		return "wrong orientation"
+	case TooFewVertices:
+		return "too few vertices"
+	}
+	return "unknown defect"
+}
+
+// EdgeRef names the edge of ring Ring that starts at vertex Index. A
+// Polygon is ring 0.
+type EdgeRef struct {
+	Ring, Index int
+}
+
+// Defect is a problem found by Validate, located at Point. Edges lists the
+// edges involved; ring-wide defects name the first edge of the ring.
+type Defect struct {
+	Kind  DefectKind
+	Point vector2.Vector2
+	Edges []EdgeRef
+}
+
+// Validate reports the defects of the polygon as a simple, counter-clockwise
+// outline. Points closer than tolerance are treated as equal. A valid
+// polygon returns no defects.
+func (p Polygon) Validate(tolerance float64) []Defect {
+	return validateRings([]Polygon{p}, tolerance)
+}
+
+// Validate reports the defects of the region's rings: crossings within and
+// between rings, duplicate vertices, zero-length edges, and outer rings or
+// holes, as nested with the even-odd rule, that are oriented the wrong way.
+func (r Region) Validate(tolerance float64) []Defect {
+	return validateRings(r.Rings, tolerance)
+}
+
+func validateRings(rings []Polygon, tolerance float64) []Defect {
+	tol := math.Max(tolerance, 1e-9*ringsExtent(rings))
+	var defects []Defect
+	var segments [][2]vector2.Vector2
+	var refs []EdgeRef
+	for r, ring := range rings {
+		n := len(ring)
+		distinct := 0
+		for i := 0; i < n; i++ {
+			a, b := ring[i], ring[(i+1)%n]
+			if a.DistanceTo(b) <= tol {
+				if n > 1 {
+					defects = append(defects, Defect{Kind: ZeroLengthEdge, Point: a, Edges: []EdgeRef{{r, i}}})
+				}
+				continue
+			}
+			distinct++
+			segments = append(segments, [2]vector2.Vector2{a, b})
+			refs = append(refs, EdgeRef{r, i})
+		}
+		if distinct < 3 {
+			point := vector2.Vector2{}
+			if n > 0 {
+				point = ring[0]
+			}
+			defects = append(defects, Defect{Kind: TooFewVertices, Point: point, Edges: []EdgeRef{{r, 0}}})
+		}
+	}
+
+	for _, x := range sweep.BentleyOttmann(segments) {
+		edges := make([]EdgeRef, len(x.Segments))
+		vertices := map[EdgeRef]bool{}
+		crossing := false
+		for k, id := range x.Segments {
+			edges[k] = refs[id]
+			ring := rings[refs[id].Ring]
+			start, end := refs[id].Index, (refs[id].Index+1)%len(ring)
+			switch {
+			case ring[start].DistanceTo(x.Point) <= tol:
+				vertices[vertexRun(ring, start, tol, refs[id].Ring)] = true
+			case ring[end].DistanceTo(x.Point) <= tol:
+				vertices[vertexRun(ring, end, tol, refs[id].Ring)] = true
+			default:
+				crossing = true
+			}
+		}
+		switch {
+		case crossing:
+			defects = append(defects, Defect{Kind: SelfIntersection, Point: x.Point, Edges: edges})
+		case len(vertices) > 1:
+			defects = append(defects, Defect{Kind: DuplicateVertex, Point: x.Point, Edges: edges})
+		}
+	}
+
+	for r, ring := range rings {
+		if len(ring) < 3 {
+			continue
+		}
+		depth := 0
+		for o, other := range rings {
+			if o != r && len(other) > 2 && pointInRings([]Polygon{other}, ring[0]) {
+				depth++
+			}
+		}
+		if area := signedArea(ring); area != 0 && (depth%2 == 0) != (area > 0) {
+			defects = append(defects, Defect{Kind: WrongOrientation, Point: ring[0], Edges: []EdgeRef{{r, 0}}})
+		}
+	}
+	return defects
+}
+
+// vertexRun returns the first vertex of the run of coincident vertices that
+// contains vertex i, so that a zero-length edge does not make its two ends
+// count as different vertices.
+func vertexRun(ring Polygon, i int, tol float64, r int) EdgeRef {
+	n := len(ring)
+	for steps := 0; steps < n && ring[(i+n-1)%n].DistanceTo(ring[i]) <= tol; steps++ {
+		i = (i + n - 1) % n
+	}
+	return EdgeRef{r, i}
+}
+
+func ringsExtent(rings []Polygon) float64 {
+	extent := 1.0
+	for _, ring := range rings {
+		for _, v := range ring {
+			extent = math.Max(extent, math.Max(math.Abs(v.X), math.Abs(v.Y)))
+		}
+	}
+	return extent
+}
+
+// Repair splits a self-intersecting polygon into simple polygons. The
+// outline is cut at every crossing and at every vertex that touches another
+// part of it, and each closed loop this separates becomes its own
+// counter-clockwise polygon, so a bowtie becomes two triangles. Zero-length
+// edges and loops without area are dropped. Loops are not tested for
+// nesting; to settle overlapping loops by a fill rule use
+// NewRegion(rule, p).Normalized() instead.
+func (p Polygon) Repair(tolerance float64) []Polygon {
+	tol := math.Max(tolerance, 1e-9*ringsExtent([]Polygon{p}))
+	ring := cleanRing(p, tol)
+	n := len(ring)
+	if n < 3 {
+		return nil
+	}
+
+	segments := make([][2]vector2.Vector2, n)
+	for i := range ring {
+		segments[i] = [2]vector2.Vector2{ring[i], ring[(i+1)%n]}
+	}
+	type split struct {
+		t     float64
+		point vector2.Vector2
+	}
+	splits := make([][]split, n)
+	for _, x := range sweep.BentleyOttmann(segments) {
+		// Use the exact vertex when the point is one, so that both visits
+		// of it compare equal below.
+		point := x.Point
+		for _, id := range x.Segments {
+			for _, v := range segments[id] {
+				if v.DistanceTo(point) <= tol {
+					point = v
+				}
+			}
+		}
+		for _, id := range x.Segments {
+			a, b := segments[id][0], segments[id][1]
+			if a.DistanceTo(point) <= tol || b.DistanceTo(point) <= tol {
+				continue
+			}
+			t := point.Sub(a).Dot(b.Sub(a)) / b.Sub(a).Dot(b.Sub(a))
+			splits[id] = append(splits[id], split{t, point})
+		}
+	}
+	var walk []vector2.Vector2
+	for i := range ring {
+		walk = append(walk, ring[i])
+		sort.Slice(splits[i], func(a, b int) bool { return splits[i][a].t < splits[i][b].t })
+		for _, s := range splits[i] {
+			walk = append(walk, s.point)
+		}
+	}
+
+	// Walking the outline, every return to a point already on the path
+	// closes a loop, which is cut off.
+	var loops []Polygon
+	var path Polygon
+	seen := map[vector2.Vector2]int{}
+	for _, v := range walk {
+		if k, ok := seen[v]; ok {
+			loops = append(loops, append(Polygon{}, path[k:]...))
+			for _, u := range path[k+1:] {
+				delete(seen, u)
+			}
+			path = path[:k+1]
+			continue
+		}
+		seen[v] = len(path)
+		path = append(path, v)
+	}
+	loops = append(loops, path)
+
+	var out []Polygon
+	for _, loop := range loops {
+		loop = cleanRing(loop, tol)
+		area := signedArea(loop)
+		if len(loop) < 3 || math.Abs(area) <= tol*tol {
+			continue
+		}
+		if area < 0 {
+			reverse(loop)
+		}
+		out = append(out, loop)
+	}
+	return out
+}
+
+// cleanRing drops vertices within tol of their predecessor, including a
+// closing vertex that repeats the first.
+func cleanRing(ring Polygon, tol float64) Polygon {
+	var out Polygon
+	for _, v := range ring {
+		if len(out) == 0 || out[len(out)-1].DistanceTo(v) > tol {
+			out = append(out, v)
+		}
+	}
+	for len(out) > 1 && out[0].DistanceTo(out[len(out)-1]) <= tol {
+		out = out[:len(out)-1]
+	}
+	return out
+}
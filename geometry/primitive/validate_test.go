@@ -0,0 +1,71 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+)
+
+func defectKinds(defects []Defect) map[DefectKind]int {
+	kinds := map[DefectKind]int{}
+	for _, d := range defects {
+		kinds[d.Kind]++
+	}
+	return kinds
+}
+
+func TestValidate(t *testing.T) {
+	hole := square(3, 3, 4)
+	for _, tt := range []struct {
+		name  string
+		rings []Polygon
+		want  map[DefectKind]int
+	}{
+		{"valid square", []Polygon{square(0, 0, 10)}, map[DefectKind]int{}},
+		{"bowtie", []Polygon{{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 10}}}, map[DefectKind]int{SelfIntersection: 1}},
+		{"pinched duplicate vertex", []Polygon{{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 5, Y: 5}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 5, Y: 5}}}, map[DefectKind]int{DuplicateVertex: 1}},
+		{"zero-length edge", []Polygon{{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}}, map[DefectKind]int{ZeroLengthEdge: 1}},
+		{"hole oriented as an outline", []Polygon{square(0, 0, 10), hole}, map[DefectKind]int{WrongOrientation: 1}},
+		{"clockwise outline", []Polygon{{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 10, Y: 10}, {X: 10, Y: 0}}}, map[DefectKind]int{WrongOrientation: 1}},
+		{"two vertices", []Polygon{{{X: 0, Y: 0}, {X: 10, Y: 0}}}, map[DefectKind]int{TooFewVertices: 1}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := defectKinds(NewRegion(EvenOdd, tt.rings...).Validate(1e-9))
+			if len(got) != len(tt.want) {
+				t.Errorf("got defects %v, want %v", got, tt.want)
+			}
+			for kind, n := range tt.want {
+				if got[kind] != n {
+					t.Errorf("%v: got %d, want %d", kind, got[kind], n)
+				}
+			}
+		})
+	}
+
+	bowtie := Polygon{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 10}}
+	for _, d := range bowtie.Validate(1e-9) {
+		if d.Kind == SelfIntersection && (d.Point.DistanceTo(vector2.Vector2{X: 5, Y: 5}) > 1e-9 || len(d.Edges) != 2 || d.Edges[0].Index != 0 || d.Edges[1].Index != 2) {
+			t.Errorf("crossing at %v between %v, want edges 0 and 2 at (5, 5)", d.Point, d.Edges)
+		}
+	}
+}
+
+func TestRepairBowtie(t *testing.T) {
+	bowtie := Polygon{{X: 0, Y: 0}, {X: 10, Y: 10}, {X: 10, Y: 0}, {X: 0, Y: 10}}
+	got := bowtie.Repair(1e-9)
+	if len(got) != 2 {
+		t.Fatalf("got %d polygons %v, want two triangles", len(got), got)
+	}
+	for i, p := range got {
+		if len(p) != 3 {
+			t.Errorf("polygon %d = %v, want a triangle", i, p)
+		}
+		if area := signedArea(p); math.Abs(area-25) > 1e-9 {
+			t.Errorf("polygon %d has signed area %g, want 25", i, area)
+		}
+		if defects := p.Validate(1e-9); len(defects) != 0 {
+			t.Errorf("polygon %d has defects %v", i, defects)
+		}
+	}
+}
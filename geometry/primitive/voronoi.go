@@ -0,0 +1,33 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/Anaxarchus/zero-gdscript/pkg/vector2"
+	"github.com/anaxarchus/MathEngine/geometry/algorithms/voronoi"
+)
+
+// VoronoiCells returns the Voronoi cell of every site clipped to bounds, in
+// the order of sites. Bounds may be concave, in which case a cell can split
+// into several pieces. Repeated sites and sites whose cell misses the bounds
+// get an empty region.
+func VoronoiCells(sites []vector2.Vector2, bounds Polygon) []Region {
+	d := voronoi.New(sites)
+	convexBounds := bounds.ConvexHull()
+	convex := math.Abs(math.Abs(signedArea(bounds))-signedArea(convexBounds)) <= 1e-9*signedArea(convexBounds)
+	area := NewRegion(NonZero, bounds)
+
+	cells := make([]Region, len(sites))
+	for i := range sites {
+		cell := Polygon(d.Cell(i, convexBounds))
+		if len(cell) == 0 {
+			continue
+		}
+		if convex {
+			cells[i] = NewRegion(NonZero, cell)
+		} else {
+			cells[i] = NewRegion(NonZero, cell).Intersection(area)
+		}
+	}
+	return cells
+}
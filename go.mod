@@ -3,11 +3,14 @@ module github.com/anaxarchus/MathEngine
 go 1.22.3
 
 require (
-	github.com/Anaxarchus/zero-gdscript v0.3.0 // indirect
-	github.com/ctessum/go.clipper v0.1.2 // indirect
+	github.com/Anaxarchus/zero-gdscript v0.3.0
+	github.com/ctessum/go.clipper v0.1.2
+	github.com/fogleman/contourmap v0.0.0-20190814184649-9f61d36c4199
+	github.com/fogleman/gg v1.3.0
+)
+
+require (
 	github.com/fogleman/colormap v0.0.0-20240324153029-3da9a245d155 // indirect
-	github.com/fogleman/contourmap v0.0.0-20190814184649-9f61d36c4199 // indirect
-	github.com/fogleman/gg v1.3.0 // indirect
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
 	golang.org/x/image v0.21.0 // indirect
 )
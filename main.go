@@ -21,17 +21,19 @@ func DumbShit() {
 	mesh.OutlineWidth = 3.0
 	mesh.OutlineColor = Color{1, 0, 0, 1}
 	mesh.Filled = false
-	mesh.Polygon = primitive.NewPolygon(
+	mesh.Region = primitive.NewRegion(primitive.NonZero, primitive.NewPolygon(
 		vector2.New(200.129, 200.129),
 		vector2.New(400.433, 200.129),
 		vector2.New(400.433, 350.912),
 		vector2.New(350.912, 350.912),
 		vector2.New(350.912, 400.433),
 		vector2.New(200.129, 400.433),
-	)
+	))
 
-	for _, pt := range mesh.Polygon {
-		fmt.Println("point: ", pt.X, ", ", pt.Y)
+	for _, ring := range mesh.Region.Rings {
+		for _, pt := range ring {
+			fmt.Println("point: ", pt.X, ", ", pt.Y)
+		}
 	}
 
 	mesh.Draw(cc)
@@ -41,10 +43,16 @@ func DumbShit() {
 	mesh.OutlineColor = Color{0, 1, 0, 1}
 	mesh.Draw(cc)
 
-	for _, pt := range mesh.Polygon {
-		fmt.Println("point: ", pt.X, ", ", pt.Y)
+	for _, ring := range mesh.Region.Rings {
+		for _, pt := range ring {
+			fmt.Println("point: ", pt.X, ", ", pt.Y)
+		}
+	}
+
+	pocket := mesh.Pocket(8.0, 4.0)
+	for _, loop := range pocket.Loops {
+		loop.Path.Draw(cc, Color{0, 0, 1, 1}, 1.0)
 	}
 
 	cc.SavePNG("temp/out.png")
-	//mesh.Pocket(8.0)
 }